@@ -4,19 +4,30 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"math/big"
 	"math/rand"
 	"os"
 	"path/filepath"
 	"sort"
-	"time"
 
 	"github.com/reallyasi9/cardware-generator/pkg/cardware"
-	"gonum.org/v1/gonum/stat/combin"
 )
 
 var flagMinWordLength int
 var flagDraws int
 var flagDeckType string
+var flagDeckFile string
+var flagSuits string
+var flagRanks string
+var flagTrumps string
+var flagCopies int
+var flagDrawMode string
+var flagJokers int
+var flagPassphraseWords int
+var flagFormat string
+var flagShuffle bool
+var flagSeedFile string
+var flagSeedOutFile string
 
 type cardWord struct {
 	cards []rune
@@ -48,18 +59,43 @@ func (c cardWordList) Swap(i, j int) {
 func init() {
 	flag.IntVar(&flagMinWordLength, "m", 4, "minimum number of letters in words")
 	flag.IntVar(&flagDraws, "n", 0, "number of card draws (limits the number of shuffled words; defaults to as many as necessary to select all words in wordlist)")
-	flag.StringVar(&flagDeckType, "t", "french", "type of deck (can be \"french\" for a standard 4-suited, 13-ranked deck or \"tarot\" for a 4-suited, 14-ranked, 22-trump deck)")
+	flag.StringVar(&flagDeckType, "t", "french", "type of deck (one of the decks listed below)")
+	flag.StringVar(&flagSuits, "suits", "", "keep only these suits of a French or tarot deck, given as suit symbols or ASCII letters (for example, \"SH\" for spades and hearts)")
+	flag.StringVar(&flagRanks, "ranks", "", "keep only these comma-separated values of a French or tarot deck (for example, \"A,7,8,9,T,J,Q,K\"; N adds knights to a French deck; tarot trumps are kept unless -trumps is given)")
+	flag.StringVar(&flagTrumps, "trumps", "", "keep only these comma-separated tarot trumps or ranges of them, numbered from 0 for the fool to 21 for the world (for example, \"0,11-21\"; \"none\" for no trumps; trumps may also be added to a French deck)")
+	flag.IntVar(&flagJokers, "jokers", 0, "number of jokers to add to a French deck (can be 0, 2 for red and black, or 3 for red, black, and white)")
+	flag.IntVar(&flagCopies, "copies", 1, "number of identical decks shuffled together")
+	flag.StringVar(&flagDeckFile, "deck", "", "load a custom deck from this JSON deck definition (overrides -t)")
+	flag.StringVar(&flagDrawMode, "draw", "ordered", "how cards are drawn (can be \"ordered\" for cards dealt one at a time, \"hand\" for a hand dealt at once and sorted before lookup, or \"replace\" for cards returned and reshuffled after each draw)")
+	flag.IntVar(&flagPassphraseWords, "p", 6, "number of words in a passphrase for the entropy report")
+	flag.BoolVar(&flagShuffle, "shuffle", false, "assign words from a shuffled deck rather than in card order (matters only when the wordlist is smaller than the number of permutations)")
+	flag.StringVar(&flagFormat, "f", "text", "output format (can be \"text\", \"json\" or \"csv\")")
+	flag.StringVar(&flagSeedFile, "seed", "", "generate reproducibly from the 256-bit hex seed in this file")
+	flag.StringVar(&flagSeedOutFile, "seed-out", "", "write the 256-bit hex seed used to generate the table to this file (a new seed is drawn unless -seed is given)")
 
 	flag.Usage = func() {
 		name := filepath.Base(os.Args[0])
-		fmt.Fprintf(os.Stderr, "Usage: %s [options] wordlist\nOptions are any of the following:\n", name)
+		fmt.Fprintf(os.Stderr, "Usage: %s [options] wordlist\n       %s passphrase [options] table\n       %s lookup [options] table [card...]\nOptions are any of the following:\n", name, name, name)
 		flag.PrintDefaults()
+		cardware.WriteDevices(os.Stderr, "Decks (-t) are any of the following:", cardware.RegisteredDecks())
 		fmt.Fprintf(os.Stderr, "Options must precede positional arguments.\n")
+		fmt.Fprintf(os.Stderr, "Run a subcommand with -h for its options.\n")
 	}
 }
 
+// commands are the subcommands of the program, selected by the first argument.
+var commands = map[string]func(args []string){
+	"passphrase": passphrase,
+	"lookup":     lookup,
+}
+
 func main() {
-	rand.Seed(time.Now().UnixNano())
+	if len(os.Args) > 1 {
+		if cmd, ok := commands[os.Args[1]]; ok {
+			cmd(os.Args[2:])
+			return
+		}
+	}
 
 	flag.Parse()
 	wordListFile := flag.Arg(0)
@@ -67,15 +103,29 @@ func main() {
 		flag.Usage()
 		log.Fatal(fmt.Errorf("word list file not specified"))
 	}
-	var deck *cardware.Deck
-	if flagDeckType == "french" {
-		deck = cardware.NewStandardFrenchDeck()
-	} else if flagDeckType == "tarot" {
-		deck = cardware.NewTarotDeMarseilleDeck()
-	} else {
+	if flagCopies < 1 {
+		flag.Usage()
+		log.Fatal(fmt.Errorf("number of decks %d not valid", flagCopies))
+	}
+	deck, err := cardware.NewDeckFromOptions(cardware.DeckOptions{Type: flagDeckType, File: flagDeckFile, Jokers: flagJokers, Suits: flagSuits, Ranks: flagRanks, Trumps: flagTrumps, Copies: flagCopies})
+	if err != nil {
+		flag.Usage()
+		log.Fatal(err)
+	}
+	if err := setDrawMode(deck, flagDrawMode); err != nil {
 		flag.Usage()
-		log.Fatal(fmt.Errorf("deck type \"%s\" not valid", flagDeckType))
+		log.Fatal(err)
 	}
+	if flagFormat != "text" && flagFormat != "json" && flagFormat != "csv" {
+		flag.Usage()
+		log.Fatal(fmt.Errorf("output format \"%s\" not valid", flagFormat))
+	}
+
+	src, seed, err := cardware.NewSource(flagSeedFile, flagSeedOutFile != "")
+	if err != nil {
+		log.Fatal(err)
+	}
+	rng := rand.New(src)
 
 	file, err := os.Open(wordListFile)
 	if err != nil {
@@ -92,43 +142,113 @@ func main() {
 		log.Printf("limiting to %d cards due to user options", flagDraws)
 		nCards = flagDraws
 	}
-	deckSize := deck.MaxDraws()
-	nWords := combin.NumPermutations(deckSize, nCards)
-	if nWords > len(wordList) {
-		log.Printf("WARNING: due to wordlist size, only %d of %d permutations will be used", len(wordList), nWords)
-		nWords = len(wordList)
+	nWords := len(wordList)
+	nPerms, err := deck.CountDistinctOutcomes(nCards)
+	if err != nil {
+		log.Fatal(fmt.Errorf("cannot draw %d cards : %v", nCards, err))
+	}
+	if nPerms.Cmp(big.NewInt(int64(nWords))) <= 0 {
+		nWords = int(nPerms.Int64())
+	} else {
+		log.Printf("WARNING: due to wordlist size, only %d of %v permutations will be used", nWords, nPerms)
+	}
+	if deck.HasDuplicates() && nCards > 1 {
+		log.Printf("WARNING: deck has duplicate cards, so draws with repeated cards are less likely than others: entropy is counted for the most likely draw")
 	}
 	log.Printf("limiting to %d words with %d cards", nWords, nCards)
 
-	rand.Shuffle(len(wordList), func(i, j int) {
+	rng.Shuffle(len(wordList), func(i, j int) {
 		wordList[i], wordList[j] = wordList[j], wordList[i]
 	})
+	if flagShuffle {
+		deck.Shuffle(src)
+	}
+
+	// the words are mapped to the first outcomes of the deck as shuffled
+	entropy, err := cardware.NewEntropy(deck, nCards, nWords)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("entropy: %.2f bits per word (%d of %v outcomes mapped)", entropy.WordBits(), nWords, entropy.Outcomes)
+	log.Printf("entropy: %.2f bits per %d-word passphrase", entropy.PassphraseBits(flagPassphraseWords, 0), flagPassphraseWords)
 
 	cwl := make(cardWordList, nWords)
+	outcomes, err := deck.Outcomes(nCards)
+	if err != nil {
+		log.Fatal(err)
+	}
 	for iWord := 0; iWord < nWords; iWord++ {
-		cards := deck.NextOutcome(nCards)
+		cards := outcomes.Next()
 		cwl[iWord] = cardWord{cards: cards, word: wordList[iWord]}
 	}
 
 	sort.Sort(cwl)
-	for _, cw := range cwl {
-		for _, c := range cw.cards {
-			cardStr, err := deck.Translate(c)
-			if err != nil {
-				log.Fatal(fmt.Errorf("card '%c' : %v", c, err))
+	// the seed is only recorded once the table can be generated
+	if flagSeedOutFile != "" {
+		if err := cardware.WriteSeedFile(flagSeedOutFile, seed); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if flagFormat != "text" {
+		var export cardware.Export
+		for _, cw := range cwl {
+			if err := export.Add(deck, cw.cards, cw.word); err != nil {
+				log.Fatal(err)
 			}
-			fmt.Printf("[%s]", cardStr)
+		}
+		if flagFormat == "json" {
+			err = export.WriteJSON(os.Stdout)
+		} else {
+			err = export.WriteCSV(os.Stdout)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	for _, cw := range cwl {
+		names, err := cardware.TranslateOutcome(deck, cw.cards)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, name := range names {
+			fmt.Printf("[%s]", name)
 		}
 		fmt.Printf(" %s\n", cw.word)
 	}
 }
 
+// setDrawMode sets how cards are drawn from the deck from the mode's name.
+func setDrawMode(deck *cardware.Deck, mode string) error {
+	switch mode {
+	case "ordered":
+		deck.SetDrawMode(cardware.Ordered)
+	case "hand":
+		deck.SetDrawMode(cardware.Unordered)
+	case "replace":
+		deck.SetDrawMode(cardware.WithReplacement)
+	default:
+		return fmt.Errorf("draw mode \"%s\" not valid", mode)
+	}
+	return nil
+}
+
 func countCardsNeeded(nCombinations int, deck *cardware.Deck) int {
 	cards := 0
-	combs := 0
-	for combs < nCombinations && cards <= deck.MaxDraws() {
+	n := big.NewInt(int64(nCombinations))
+	prev := big.NewInt(0)
+	for cards < deck.MaxDraws() {
+		count, err := deck.CountDistinctOutcomes(cards)
+		if err != nil || count.Cmp(n) >= 0 {
+			break
+		}
+		if count.Cmp(prev) <= 0 {
+			// drawing more cards does not give more outcomes
+			return cards - 1
+		}
+		prev = count
 		cards++
-		combs = combin.NumPermutations(deck.MaxDraws(), cards)
 	}
 	return cards
 }
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/reallyasi9/cardware-generator/pkg/cardware"
+)
+
+// lookup translates typed card sequences into words from a table.
+func lookup(args []string) {
+	fs := flag.NewFlagSet("lookup", flag.ExitOnError)
+	deckType := fs.String("t", "french", "type of deck the table was generated for (one of the decks listed below)")
+	deckFile := fs.String("deck", "", "JSON definition of the custom deck the table was generated for (overrides -t)")
+	suits := fs.String("suits", "", "suits of the stripped French or tarot deck the table was generated for")
+	ranks := fs.String("ranks", "", "comma-separated values of the stripped French or tarot deck the table was generated for")
+	trumps := fs.String("trumps", "", "trumps of the stripped French or tarot deck the table was generated for")
+	jokers := fs.Int("jokers", 0, "number of jokers in the French deck the table was generated for (0, 2 or 3)")
+	copies := fs.Int("copies", 1, "number of identical decks shuffled together when the table was generated")
+	drawMode := fs.String("draw", "ordered", "how cards were drawn when the table was generated (\"ordered\", \"hand\" or \"replace\")")
+	coins := fs.Int("coins", 0, "number of coins flipped when the table was generated by cardware-generator")
+	dice := fs.String("d", "", "dice rolled when the table was generated by cardware-generator, as given to its -d option")
+	unorderedDice := fs.Bool("unordered-dice", false, "identical dice (and coins) were rolled together when the table was generated by cardware-generator")
+	fs.Usage = func() {
+		name := filepath.Base(os.Args[0])
+		fmt.Fprintf(os.Stderr, "Usage: %s lookup [options] table [card...]\nOptions are any of the following:\n", name)
+		fs.PrintDefaults()
+		cardware.WriteDevices(os.Stderr, "Decks (-t) are any of the following:", cardware.RegisteredDecks())
+		cardware.WriteDevices(os.Stderr, "Named dice (-d [N]dNAME) are any of the following:", cardware.RegisteredDice())
+		fmt.Fprintf(os.Stderr, "Options must precede positional arguments.\n")
+		fmt.Fprintf(os.Stderr, "Cards are given as names (A♠ T♡ 3♣) or with ASCII suits (AS TH 3C), after the faces of any coins and dice ([H] [6] or H 6).\n")
+		fmt.Fprintf(os.Stderr, "If no cards are given, one sequence of cards is read from each line of standard input.\n")
+	}
+	fs.Parse(args)
+
+	tableFile := fs.Arg(0)
+	if tableFile == "" {
+		fs.Usage()
+		log.Fatal(fmt.Errorf("table file not specified"))
+	}
+	deck, err := cardware.NewDeckFromOptions(cardware.DeckOptions{Type: *deckType, File: *deckFile, Jokers: *jokers, Suits: *suits, Ranks: *ranks, Trumps: *trumps, Copies: *copies})
+	if err != nil {
+		fs.Usage()
+		log.Fatal(err)
+	}
+	if err := setDrawMode(deck, *drawMode); err != nil {
+		fs.Usage()
+		log.Fatal(err)
+	}
+	bag, err := cardware.NewDiceFromOptions(cardware.DiceOptions{Coins: *coins, Dice: *dice, Unordered: *unorderedDice})
+	if err != nil {
+		fs.Usage()
+		log.Fatal(err)
+	}
+	device := cardware.NewCombinedFrom(bag, deck)
+
+	file, err := os.Open(tableFile)
+	if err != nil {
+		log.Fatal(fmt.Errorf("table file '%s' : %v", tableFile, err))
+	}
+	defer file.Close()
+
+	table, err := cardware.ReadTable(file)
+	if err != nil {
+		log.Fatal(fmt.Errorf("table file '%s' : %v", tableFile, err))
+	}
+
+	if fs.NArg() > 1 {
+		word, err := table.LookupTyped(device, fs.Args()[1:])
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(word)
+		return
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		cards := strings.Fields(scanner.Text())
+		if len(cards) == 0 {
+			continue
+		}
+		word, err := table.LookupTyped(device, cards)
+		if err != nil {
+			log.Print(err)
+			continue
+		}
+		fmt.Println(word)
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+}
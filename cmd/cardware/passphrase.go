@@ -0,0 +1,139 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/reallyasi9/cardware-generator/pkg/cardware"
+)
+
+// passphrase simulates rolling the dice and drawing the cards a table was
+// generated for to pick words from it.
+func passphrase(args []string) {
+	fs := flag.NewFlagSet("passphrase", flag.ExitOnError)
+	deckType := fs.String("t", "french", "type of deck the table was generated for (one of the decks listed below)")
+	deckFile := fs.String("deck", "", "JSON definition of the custom deck the table was generated for (overrides -t)")
+	suits := fs.String("suits", "", "suits of the stripped French or tarot deck the table was generated for")
+	ranks := fs.String("ranks", "", "comma-separated values of the stripped French or tarot deck the table was generated for")
+	trumps := fs.String("trumps", "", "trumps of the stripped French or tarot deck the table was generated for")
+	jokers := fs.Int("jokers", 0, "number of jokers in the French deck the table was generated for (0, 2 or 3)")
+	copies := fs.Int("copies", 1, "number of identical decks shuffled together when the table was generated")
+	drawMode := fs.String("draw", "ordered", "how cards were drawn when the table was generated (\"ordered\", \"hand\" or \"replace\")")
+	coins := fs.Int("coins", 0, "number of coins flipped when the table was generated by cardware-generator")
+	dice := fs.String("d", "", "dice rolled when the table was generated by cardware-generator, as given to its -d option")
+	unorderedDice := fs.Bool("unordered-dice", false, "identical dice (and coins) were rolled together when the table was generated by cardware-generator")
+	nWords := fs.Int("n", 6, "number of words in the passphrase")
+	fs.Usage = func() {
+		name := filepath.Base(os.Args[0])
+		fmt.Fprintf(os.Stderr, "Usage: %s passphrase [options] table\nOptions are any of the following:\n", name)
+		fs.PrintDefaults()
+		cardware.WriteDevices(os.Stderr, "Decks (-t) are any of the following:", cardware.RegisteredDecks())
+		cardware.WriteDevices(os.Stderr, "Named dice (-d [N]dNAME) are any of the following:", cardware.RegisteredDice())
+		fmt.Fprintf(os.Stderr, "Options must precede positional arguments.\n")
+	}
+	fs.Parse(args)
+
+	tableFile := fs.Arg(0)
+	if tableFile == "" {
+		fs.Usage()
+		log.Fatal(fmt.Errorf("table file not specified"))
+	}
+	deck, err := cardware.NewDeckFromOptions(cardware.DeckOptions{Type: *deckType, File: *deckFile, Jokers: *jokers, Suits: *suits, Ranks: *ranks, Trumps: *trumps, Copies: *copies})
+	if err != nil {
+		fs.Usage()
+		log.Fatal(err)
+	}
+	if err := setDrawMode(deck, *drawMode); err != nil {
+		fs.Usage()
+		log.Fatal(err)
+	}
+	bag, err := cardware.NewDiceFromOptions(cardware.DiceOptions{Coins: *coins, Dice: *dice, Unordered: *unorderedDice})
+	if err != nil {
+		fs.Usage()
+		log.Fatal(err)
+	}
+	device := cardware.NewCombinedFrom(bag, deck)
+
+	file, err := os.Open(tableFile)
+	if err != nil {
+		log.Fatal(fmt.Errorf("table file '%s' : %v", tableFile, err))
+	}
+	defer file.Close()
+
+	table, err := cardware.ReadTable(file)
+	if err != nil {
+		log.Fatal(fmt.Errorf("table file '%s' : %v", tableFile, err))
+	}
+	nDraws := table.Draws()
+	if nDraws < bag.MaxDraws() || nDraws > device.MaxDraws() || nDraws == 0 {
+		log.Fatal(fmt.Errorf("table file '%s' : cannot draw %d from %d coins and dice and a %d-card deck", tableFile, nDraws, bag.MaxDraws(), deck.MaxDraws()))
+	}
+	nOutcomes, err := device.CountDistinctOutcomes(nDraws)
+	if err != nil {
+		log.Fatal(err)
+	}
+	limit := maxMisses(nOutcomes, len(table))
+	log.Printf("read %d words drawn with %d coins and dice and %d cards", len(table), bag.MaxDraws(), nDraws-bag.MaxDraws())
+
+	var src cardware.CryptoSource
+	words := make([]string, 0, *nWords)
+	misses := int64(0)
+	for len(words) < *nWords {
+		outcome, err := device.RandomOutcome(nDraws, src)
+		if err != nil {
+			log.Fatal(err)
+		}
+		names, err := cardware.TranslateOutcome(device, outcome)
+		if err != nil {
+			log.Fatal(err)
+		}
+		word, ok := table.Lookup(names)
+		if !ok {
+			// unmapped outcomes are redrawn so that every word remains equally likely
+			log.Printf("%s not in table, drawing again", bracket(names))
+			misses++
+			if misses >= limit {
+				log.Fatal(fmt.Errorf("table file '%s' : no word found in %d draws; was the table generated for these dice, deck and draw mode?", tableFile, misses))
+			}
+			continue
+		}
+		misses = 0
+		fmt.Printf("%s %s\n", bracket(names), word)
+		words = append(words, word)
+	}
+	fmt.Printf("\n%s\n", strings.Join(words, " "))
+}
+
+// bracket prints the names of an outcome each in square brackets, as tables
+// print cards. Die and coin faces are already translated in brackets.
+func bracket(names []string) string {
+	var b strings.Builder
+	for _, name := range names {
+		if strings.HasPrefix(name, "[") {
+			b.WriteString(name)
+			continue
+		}
+		b.WriteString("[" + name + "]")
+	}
+	return b.String()
+}
+
+// maxMisses returns the number of draws in a row that may miss a table of n
+// entries before giving up. A draw from the deck the table was generated for
+// is found in it with a chance of about n in the number of outcomes, so such a
+// table is all but certain to be hit well within this many draws.
+func maxMisses(outcomes *big.Int, n int) int64 {
+	m := new(big.Int).Quo(outcomes, big.NewInt(int64(n)))
+	m.Add(m, big.NewInt(1))
+	m.Mul(m, big.NewInt(100))
+	if !m.IsInt64() {
+		return math.MaxInt64
+	}
+	return m.Int64()
+}
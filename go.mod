@@ -1,6 +1,6 @@
 module github.com/reallyasi9/cardware-generator
 
-go 1.12
+go 1.13
 
 require (
 	golang.org/x/exp v0.0.0-20191227195350-da58074b4299 // indirect
@@ -2,7 +2,6 @@ package main
 
 import (
 	"bufio"
-	"encoding/binary"
 	"flag"
 	"fmt"
 	"log"
@@ -10,66 +9,27 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
-	"regexp"
 	"sort"
-	"strconv"
 	"strings"
 
 	"github.com/reallyasi9/cardware-generator/pkg/cardware"
-
-	crand "crypto/rand"
 )
 
 var symbols = []rune{'!', '@', '#', '$', '%', '^', '&', '*', '(', ')', '_', '-', '+', '=', '~', '{', '[', '}', ']', '|', '\\', ':', ';', '<', ',', '>', '.', '?', '/'}
 var quotes = []rune{'`', '\'', '"'}
 
-type diceBag struct {
-	dice []int
-}
+// diceBag collects the dice given with -d, which may be given more than once.
+type diceBag []string
 
 func (db diceBag) String() string {
-	m := make(map[int]int)
-	for _, d := range db.dice {
-		m[d]++
-	}
-	k := make([]int, 0)
-	for key := range m {
-		k = append(k, key)
-	}
-	sort.Ints(k)
-	s := make([]string, len(k))
-	for _, die := range k {
-		s = append(s, fmt.Sprintf("%dd%d", m[die], die))
-	}
-	return strings.Join(s, "+")
+	return strings.Join(db, "+")
 }
 
 func (db *diceBag) Set(s string) error {
-	re := regexp.MustCompile(`(\d+)?[dD](\d+)`)
-	for _, val := range strings.Split(s, "+") {
-		m := re.FindStringSubmatch(val)
-		if m == nil {
-			return fmt.Errorf("invalid dice identifier '%s'", val)
-		}
-		n := 1
-		var err error
-		if m[1] != "" {
-			n, err = strconv.Atoi(m[1])
-			if err != nil {
-				return err
-			}
-		}
-		die, err := strconv.Atoi(m[2])
-		if err != nil {
-			return err
-		}
-		if db.dice == nil {
-			db.dice = make([]int, 0)
-		}
-		for i := 0; i < n; i++ {
-			db.dice = append(db.dice, die)
-		}
+	if _, err := cardware.ParseDice(s); err != nil {
+		return err
 	}
+	*db = append(*db, s)
 	return nil
 }
 
@@ -115,6 +75,19 @@ var flagSpace bool
 var flagNoCapitals bool
 var flagCards int
 var flagDiceBag diceBag
+var flagJokers int
+var flagDeckType string
+var flagDeckFile string
+var flagSuits string
+var flagRanks string
+var flagTrumps string
+var flagCoins int
+var flagUnorderedDice bool
+var flagPassphraseWords int
+var flagFormat string
+var flagShuffle bool
+var flagSeedFile string
+var flagSeedOutFile string
 
 func init() {
 	flag.IntVar(&flagMinWordLength, "m", 4, "minimum number of letters in words")
@@ -123,12 +96,27 @@ func init() {
 	flag.BoolVar(&flagSpace, "space", false, "allow space character in symbol table")
 	flag.BoolVar(&flagNoCapitals, "no-capitals", false, "do not create a capital letter table")
 	flag.IntVar(&flagCards, "c", 0, "draw this many playing cards to augment randomness")
-	flag.Var(&flagDiceBag, "d", "define bag of dice (using [N]dF+[N]dF+... notation)")
+	flag.StringVar(&flagDeckType, "t", "french", "type of deck (one of the decks listed below)")
+	flag.StringVar(&flagDeckFile, "deck", "", "load a custom deck from this JSON deck definition (overrides -t)")
+	flag.StringVar(&flagSuits, "suits", "", "keep only these suits of a French or tarot deck, given as suit symbols or ASCII letters (for example, \"SH\" for spades and hearts)")
+	flag.StringVar(&flagRanks, "ranks", "", "keep only these comma-separated values of a French or tarot deck (for example, \"A,7,8,9,T,J,Q,K\"; N adds knights to a French deck; tarot trumps are kept unless -trumps is given)")
+	flag.StringVar(&flagTrumps, "trumps", "", "keep only these comma-separated tarot trumps or ranges of them, numbered from 0 for the fool to 21 for the world (for example, \"0,11-21\"; \"none\" for no trumps; trumps may also be added to a French deck)")
+	flag.IntVar(&flagJokers, "jokers", 0, "number of jokers to add to a French deck (can be 0, 2 for red and black, or 3 for red, black, and white)")
+	flag.IntVar(&flagCoins, "coins", 0, "flip this many coins to augment randomness (coins are flipped before rolling dice)")
+	flag.BoolVar(&flagUnorderedDice, "unordered-dice", false, "roll identical dice (and coins) together, so their order does not matter (rolls that are not mapped to words must be rerolled)")
+	flag.Var(&flagDiceBag, "d", "define bag of dice (using [N]dX+[N]dX+... notation, where X is a number of faces, the name of a die listed below, or {a,b,...} for labeled faces)")
+	flag.IntVar(&flagPassphraseWords, "p", 6, "number of words in a passphrase for the entropy report (symbols are assumed between words)")
+	flag.BoolVar(&flagShuffle, "shuffle", false, "assign words from a shuffled deck rather than in card order")
+	flag.StringVar(&flagFormat, "f", "text", "output format (can be \"text\", \"json\" or \"csv\")")
+	flag.StringVar(&flagSeedFile, "seed", "", "generate reproducibly from the 256-bit hex seed in this file")
+	flag.StringVar(&flagSeedOutFile, "seed-out", "", "write the 256-bit hex seed used to generate the table to this file (a new seed is drawn unless -seed is given)")
 
 	flag.Usage = func() {
 		name := filepath.Base(os.Args[0])
 		fmt.Fprintf(os.Stderr, "Usage: %s [options] wordlist\nOptions are any of the following:\n", name)
 		flag.PrintDefaults()
+		cardware.WriteDevices(os.Stderr, "Decks (-t) are any of the following:", cardware.RegisteredDecks())
+		cardware.WriteDevices(os.Stderr, "Named dice (-d [N]dNAME) are any of the following:", cardware.RegisteredDice())
 		fmt.Fprintf(os.Stderr, "Options must preceed positional arguments.\n")
 	}
 }
@@ -140,6 +128,15 @@ func main() {
 		flag.Usage()
 		log.Fatal(fmt.Errorf("word list file not specified"))
 	}
+	if flagFormat != "text" && flagFormat != "json" && flagFormat != "csv" {
+		flag.Usage()
+		log.Fatal(fmt.Errorf("output format \"%s\" not valid", flagFormat))
+	}
+
+	src, seed, err := cardware.NewSource(flagSeedFile, flagSeedOutFile != "")
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	file, err := os.Open(wordListFile)
 	if err != nil {
@@ -162,13 +159,32 @@ func main() {
 
 	log.Printf("read %d words", len(wordList))
 
-	device := cardware.NewCombined(flagDiceBag.dice)
+	deck, err := cardware.NewDeckFromOptions(cardware.DeckOptions{Type: flagDeckType, File: flagDeckFile, Jokers: flagJokers, Suits: flagSuits, Ranks: flagRanks, Trumps: flagTrumps})
+	if err != nil {
+		flag.Usage()
+		log.Fatal(err)
+	}
+	bag, err := cardware.NewDiceFromOptions(cardware.DiceOptions{Coins: flagCoins, Dice: flagDiceBag.String(), Unordered: flagUnorderedDice})
+	if err != nil {
+		flag.Usage()
+		log.Fatal(err)
+	}
+	if flagUnorderedDice {
+		if p := bag.MappedProbability(bag.MaxDraws()); p.Cmp(big.NewRat(1, 1)) < 0 {
+			f, _ := p.Float64()
+			log.Printf("WARNING: only the most common kind of unordered roll is mapped to words: %.1f%% of rolls must be rerolled", 100*(1-f))
+		}
+	}
+	device := cardware.NewCombinedFrom(bag, deck)
 	log.Printf("using deck: %v", device.Deck)
 	log.Printf("using dice: %v", device.DiceBag)
 
 	// figure out number of draws
 	kdraws := device.DiceBag.MaxDraws() + flagCards
-	nWords := device.CountDistinctOutcomes(kdraws)
+	nWords, err := device.CountDistinctOutcomes(kdraws)
+	if err != nil {
+		log.Fatal(fmt.Errorf("cannot draw %d cards : %v", flagCards, err))
+	}
 	bigLen := big.NewInt(int64(len(wordList)))
 
 	if !nWords.IsInt64() || nWords.Cmp(bigLen) > 0 {
@@ -178,34 +194,126 @@ func main() {
 
 	log.Printf("drawing a total of %d words", nSubset)
 
+	// the symbol table has a cell for every value and color of the deck
+	values := deck.Values()
+	colors := deck.Colors()
+	if flagQuotes {
+		symbols = append(symbols, quotes...)
+	}
+	if flagSpace {
+		symbols = append(symbols, ' ')
+	}
+	nSymbols := len(values) * len(colors)
+	if !flagNoSymbols && nSymbols > len(symbols) {
+		log.Printf("WARNING: only %d of %d symbol table cells can be filled: cards landing on empty cells must be redrawn", len(symbols), nSymbols)
+		nSymbols = len(symbols)
+	}
+	capitals := !flagNoCapitals
+	if capitals && len(colors) != 2 {
+		log.Printf("WARNING: deck has %d colors rather than 2: not creating a capital letter table", len(colors))
+		capitals = false
+	}
+
 	// shuffle and select words from the wordlist
-	var src cryptoSource
 	rng := rand.New(src)
 	rng.Shuffle(len(wordList), func(i, j int) {
 		wordList[i], wordList[j] = wordList[j], wordList[i]
 	})
+	if flagShuffle {
+		device.Deck.Shuffle(src)
+	}
+
+	// the words are mapped to the first outcomes of the device as shuffled
+	entropy, err := cardware.NewEntropy(device, kdraws, int(nSubset))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !flagNoSymbols {
+		entropy.Symbols = nSymbols
+	}
+	// no card is drawn for the capital rule alone, so it adds no bits
+	entropy.Capitals = capitals
+	logEntropy(entropy, flagPassphraseWords)
+
 	subset := wordList[:nSubset]
 	// sot back into alphabetical order for display
 	sort.Strings(subset)
 
 	// generate permutations
 	els := make(elements, nSubset)
-	for i, draw := int64(0), device.NextOutcome(kdraws); i < nSubset && draw != nil; i, draw = i+1, device.NextOutcome(kdraws) {
+	outcomes, err := device.Outcomes(kdraws)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for i, draw := int64(0), outcomes.Next(); i < nSubset && draw != nil; i, draw = i+1, outcomes.Next() {
 		els[i] = draw
 	}
 
 	// sort by card order
 	sort.Sort(els)
 
+	// generate symbols
+	var symbolTable []cardware.ExportSymbol
+	if !flagNoSymbols {
+		rng.Shuffle(len(symbols), func(i, j int) {
+			symbols[i], symbols[j] = symbols[j], symbols[i]
+		})
+		for i, val := range values {
+			for j, col := range colors {
+				cell := i*len(colors) + j
+				if cell >= nSymbols {
+					break
+				}
+				symbolTable = append(symbolTable, cardware.ExportSymbol{
+					Value:  val,
+					Color:  col,
+					Symbol: string(symbols[cell]),
+				})
+			}
+		}
+	}
+
+	// generate capitals
+	capital := ""
+	if capitals {
+		if rng.Float32() < .5 {
+			capital = colors[0]
+		} else {
+			capital = colors[1]
+		}
+	}
+
+	// the seed is only recorded once the table can be generated
+	if flagSeedOutFile != "" {
+		if err := cardware.WriteSeedFile(flagSeedOutFile, seed); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if flagFormat != "text" {
+		export := cardware.Export{Symbols: symbolTable, Capital: capital}
+		for i, e := range els {
+			if err := export.Add(device, e, subset[i]); err != nil {
+				log.Fatalf("error translating rune : %v", err)
+			}
+		}
+		if flagFormat == "json" {
+			err = export.WriteJSON(os.Stdout)
+		} else {
+			err = export.WriteCSV(os.Stdout)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	// convert to human-readable strings
 	list := make([]string, nSubset)
 	for i, e := range els {
-		names := make([]string, len(e))
-		for j, r := range e {
-			names[j], err = device.Translate(r)
-			if err != nil {
-				log.Fatalf("error translating rune : %v", err)
-			}
+		names, err := cardware.TranslateOutcome(device, e)
+		if err != nil {
+			log.Fatalf("error translating rune : %v", err)
 		}
 		list[i] = strings.Join(names, "+")
 	}
@@ -215,57 +323,46 @@ func main() {
 		fmt.Printf("%s %s\n", draw, subset[i])
 	}
 
-	// generate symbols
-	if !flagNoSymbols {
-		if flagQuotes {
-			symbols = append(symbols, quotes...)
-		}
-		if flagSpace {
-			symbols = append(symbols, ' ')
-		}
-		rng.Shuffle(len(symbols), func(i, j int) {
-			symbols[i], symbols[j] = symbols[j], symbols[i]
-		})
-
+	// print symbols
+	if len(symbolTable) > 0 {
 		fmt.Print("\n ")
-		for _, col := range cardware.FrenchColors {
-			fmt.Printf("  %c", col)
+		for _, col := range colors {
+			fmt.Printf("  %s", col)
 		}
 		fmt.Println()
 
-		for i, val := range cardware.FrenchValues {
-			fmt.Printf("%c", val)
-			for j := range cardware.FrenchColors {
-				fmt.Printf("  %c", symbols[i*len(cardware.FrenchColors)+j])
+		for i, val := range values {
+			fmt.Printf("%s", val)
+			for j := range colors {
+				sym := " "
+				if cell := i*len(colors) + j; cell < len(symbolTable) {
+					sym = symbolTable[cell].Symbol
+				}
+				fmt.Printf("  %s", sym)
 			}
 			fmt.Println()
 		}
 	}
 
-	// generate capitals
-	if !flagNoCapitals {
+	// print capitals
+	if capital != "" {
 		fmt.Println()
-		blackCap := rng.Float32() < .5
-		if blackCap {
-			fmt.Println("CAPITAL: B")
-		} else {
-			fmt.Println("CAPITAL: R")
-		}
+		fmt.Printf("CAPITAL: %s\n", capital)
 	}
 }
 
-type cryptoSource struct{}
-
-func (s cryptoSource) Seed(seed int64) {}
-
-func (s cryptoSource) Int63() int64 {
-	return int64(s.Uint64() & ^uint64(1<<63))
-}
-
-func (s cryptoSource) Uint64() (v uint64) {
-	err := binary.Read(crand.Reader, binary.BigEndian, &v)
-	if err != nil {
-		log.Fatal(err)
+func logEntropy(e cardware.Entropy, words int) {
+	symbols := 0
+	if e.Symbols > 0 && words > 1 {
+		symbols = words - 1
+	}
+	log.Printf("entropy: %.2f bits per lookup (%d of %v outcomes mapped)", e.LookupBits(), e.Words, e.Outcomes)
+	log.Printf("entropy: %.2f bits per word", e.WordBits())
+	if e.Capitals && e.CapitalBits() == 0 {
+		log.Printf("entropy: capital rule adds no bits: capitalization follows from the color of a card already drawn")
+	}
+	if e.Symbols > 0 {
+		log.Printf("entropy: %.2f bits per symbol", e.SymbolBits())
 	}
-	return v
+	log.Printf("entropy: %.2f bits per %d-word passphrase with %d symbols", e.PassphraseBits(words, symbols), words, symbols)
 }
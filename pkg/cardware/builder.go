@@ -0,0 +1,295 @@
+package cardware
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DeckBuilder builds stripped and partial decks from the cards of the French
+// and Tarot de Marseille decks, keeping only the cards of the chosen suits,
+// ranks, and trumps. Built decks translate only their own cards, with French or
+// Tarot de Marseille suits depending on how the builder was created.
+type DeckBuilder struct {
+	tarot  bool
+	suits  []Suit
+	ranks  []Rank
+	trumps []int
+}
+
+// NewFrenchDeckBuilder returns a builder that starts from the 52 standard French
+// cards. Knights and trumps can be added with Ranks and Trumps.
+func NewFrenchDeckBuilder() *DeckBuilder {
+	return &DeckBuilder{
+		suits: []Suit{Spades, Hearts, Diamonds, Clubs},
+		ranks: []Rank{Ace, Two, Three, Four, Five, Six, Seven, Eight, Nine, Ten, Jack, Queen, King},
+	}
+}
+
+// NewTarotDeckBuilder returns a builder that starts from the 78 Tarot de
+// Marseille cards.
+func NewTarotDeckBuilder() *DeckBuilder {
+	b := &DeckBuilder{
+		tarot:  true,
+		suits:  []Suit{Spades, Hearts, Diamonds, Clubs},
+		ranks:  []Rank{Ace, Two, Three, Four, Five, Six, Seven, Eight, Nine, Ten, Jack, Knight, Queen, King},
+		trumps: make([]int, len(TarotDeMarseilleTrumps)),
+	}
+	for i := range b.trumps {
+		b.trumps[i] = i
+	}
+	return b
+}
+
+// Suits keeps only the cards of the given suits.
+func (b *DeckBuilder) Suits(suits ...Suit) *DeckBuilder {
+	b.suits = append([]Suit(nil), suits...)
+	return b
+}
+
+// Ranks keeps only the suited cards of the given ranks.
+func (b *DeckBuilder) Ranks(ranks ...Rank) *DeckBuilder {
+	b.ranks = append([]Rank(nil), ranks...)
+	return b
+}
+
+// Trumps keeps only the given trumps, numbered from 0 for the fool to 21 for
+// the world. With no arguments, the deck has no trumps.
+func (b *DeckBuilder) Trumps(trumps ...int) *DeckBuilder {
+	b.trumps = append([]int(nil), trumps...)
+	return b
+}
+
+// ParseSuits keeps only the cards of the suits in s, given as suit symbols or
+// their ASCII letters (for example, "SH" or "♠♡" for spades and hearts).
+func (b *DeckBuilder) ParseSuits(s string) error {
+	symbols, ascii := b.suitNames()
+	suits := make([]Suit, 0, 4)
+	for _, r := range strings.ToUpper(s) {
+		suit := NoSuit
+		for i := range symbols {
+			if r == symbols[i] || r == ascii[i] {
+				suit = Spades + Suit(i)
+			}
+		}
+		if suit == NoSuit {
+			return fmt.Errorf("suit '%c' is %w", r, ErrOutOfBounds)
+		}
+		suits = append(suits, suit)
+	}
+	b.Suits(suits...)
+	return nil
+}
+
+// ParseRanks keeps only the suited cards of the comma-separated values in s,
+// given as in TarotDeMarseilleValues (for example, "A,7,8,9,T,J,Q,K"). "10" may
+// be given for "T".
+func (b *DeckBuilder) ParseRanks(s string) error {
+	ranks := make([]Rank, 0, 14)
+	for _, v := range strings.Split(strings.ToUpper(s), ",") {
+		v = strings.TrimSpace(v)
+		if v == "10" {
+			v = "T"
+		}
+		rank := NoRank
+		for i, value := range TarotDeMarseilleValues {
+			if v == string(value) {
+				rank = Ace + Rank(i)
+			}
+		}
+		if rank == NoRank {
+			return fmt.Errorf("value \"%s\" is %w", v, ErrOutOfBounds)
+		}
+		ranks = append(ranks, rank)
+	}
+	b.Ranks(ranks...)
+	return nil
+}
+
+// ParseTrumps keeps only the trumps in s, given as a comma-separated list of
+// trump numbers and ranges of them, from 0 for the fool to 21 for the world
+// (for example, "0,11-21"). "none" keeps no trumps.
+func (b *DeckBuilder) ParseTrumps(s string) error {
+	if strings.EqualFold(strings.TrimSpace(s), "none") {
+		b.Trumps()
+		return nil
+	}
+	trumps := make([]int, 0, len(TarotDeMarseilleTrumps))
+	for _, v := range strings.Split(s, ",") {
+		v = strings.TrimSpace(v)
+		lo, hi := v, v
+		if i := strings.Index(v, "-"); i > 0 {
+			lo, hi = strings.TrimSpace(v[:i]), strings.TrimSpace(v[i+1:])
+		}
+		first, err := strconv.Atoi(lo)
+		if err != nil {
+			return fmt.Errorf("trump \"%s\" is %w", v, ErrOutOfBounds)
+		}
+		last, err := strconv.Atoi(hi)
+		if err != nil {
+			return fmt.Errorf("trump \"%s\" is %w", v, ErrOutOfBounds)
+		}
+		if first < 0 || last >= len(TarotDeMarseilleTrumps) || first > last {
+			return fmt.Errorf("trump \"%s\" is %w", v, ErrOutOfBounds)
+		}
+		for t := first; t <= last; t++ {
+			trumps = append(trumps, t)
+		}
+	}
+	b.Trumps(trumps...)
+	return nil
+}
+
+func (b *DeckBuilder) suitNames() ([]rune, []rune) {
+	if b.tarot {
+		return TarotDeMarseilleSuits, TarotDeMarseilleSuitsASCII
+	}
+	return FrenchSuits, FrenchSuitsASCII
+}
+
+// Build builds the deck. It returns an error if a suit, rank, or trump is not
+// valid or if no cards are kept.
+func (b *DeckBuilder) Build() (*Deck, error) {
+	for _, s := range b.suits {
+		if s < Spades || s > Clubs {
+			return nil, fmt.Errorf("suit %d is %w", s, ErrOutOfBounds)
+		}
+	}
+	for _, r := range b.ranks {
+		if r < Ace || r > King {
+			return nil, fmt.Errorf("rank %d is %w", r, ErrOutOfBounds)
+		}
+	}
+	for _, t := range b.trumps {
+		if t < 0 || t >= len(TarotDeMarseilleTrumps) {
+			return nil, fmt.Errorf("trump %d is %w", t, ErrOutOfBounds)
+		}
+	}
+	d := b.build()
+	if len(d.cards) == 0 {
+		return nil, fmt.Errorf("no suited cards or trumps kept: %w", ErrEmptyDeck)
+	}
+	return d, nil
+}
+
+// mustBuild builds the deck of a preset. The presets are fixed, so an error
+// building one is a bug.
+func mustBuild(b *DeckBuilder) *Deck {
+	d, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// build builds the deck without checking the builder's suits, ranks, and trumps.
+func (b *DeckBuilder) build() *Deck {
+	suits := make(map[Suit]bool)
+	for _, s := range b.suits {
+		suits[s] = true
+	}
+	ranks := make(map[Rank]bool)
+	for _, r := range b.ranks {
+		ranks[r] = true
+	}
+	trumps := make(map[int]bool)
+	for _, t := range b.trumps {
+		trumps[t] = true
+	}
+
+	cards := make([]Card, 0)
+	in := make(map[Card]bool)
+	for _, r := range TarotDeMarseilleCards {
+		c := Card(r)
+		if (c.IsTrump() && trumps[c.Trump()]) || (suits[c.Suit()] && ranks[c.Rank()]) {
+			cards = append(cards, c)
+			in[c] = true
+		}
+	}
+
+	values := make([]string, 0)
+	for r := Ace; r <= King; r++ {
+		if ranks[r] && len(suits) > 0 {
+			values = append(values, string(TarotDeMarseilleValues[r-Ace]))
+		}
+	}
+	colors := make([]string, 0)
+	for i, color := range b.colorNames() {
+		for s := range suits {
+			if b.colorOf(s) == i {
+				colors = append(colors, color)
+				break
+			}
+		}
+	}
+
+	symbols, ascii := b.suitNames()
+	return &Deck{
+		cards: cards,
+		tr: func(r rune) (string, error) {
+			c := Card(r)
+			if !in[c] {
+				return "", fmt.Errorf("card '%c' is %w", r, ErrOutOfBounds)
+			}
+			if c.IsTrump() {
+				return TarotDeMarseilleTrumps[c.Trump()], nil
+			}
+			return string(TarotDeMarseilleValues[c.Rank()-Ace]) + string(symbols[c.Suit()]), nil
+		},
+		parse: func(name string) (Card, error) {
+			return parseCard(name, symbols, ascii)
+		},
+		values: values,
+		colors: colors,
+	}
+}
+
+func (b *DeckBuilder) colorNames() []string {
+	if b.tarot {
+		return runeStrings(TarotDeMarseilleColors)
+	}
+	return runeStrings(FrenchColors)
+}
+
+// colorOf returns the index of the color of a suit in colorNames.
+func (b *DeckBuilder) colorOf(s Suit) int {
+	if b.tarot {
+		// long suits, then round suits
+		return int(s) / 2
+	}
+	c, _ := NewCard(Ace, s)
+	if c.Color() == Red {
+		return 1
+	}
+	return 0
+}
+
+// NewPiquetDeck builds a 32-card piquet deck, also used for skat: the seven
+// through king and the ace of each French suit.
+func NewPiquetDeck() *Deck {
+	return mustBuild(NewFrenchDeckBuilder().Ranks(Ace, Seven, Eight, Nine, Ten, Jack, Queen, King))
+}
+
+// NewEuchreDeck builds a 24-card euchre deck: the nine through king and the
+// ace of each French suit.
+func NewEuchreDeck() *Deck {
+	return mustBuild(NewFrenchDeckBuilder().Ranks(Ace, Nine, Ten, Jack, Queen, King))
+}
+
+// NewFrenchDeckWithKnights builds a 56-card deck of French suits with a knight
+// (named N) between the jack and queen of each suit.
+func NewFrenchDeckWithKnights() *Deck {
+	return mustBuild(NewFrenchDeckBuilder().Ranks(Ace, Two, Three, Four, Five, Six, Seven, Eight, Nine, Ten, Jack, Knight, Queen, King))
+}
+
+// NewTarotMajorArcanaDeck builds a deck of the 22 trumps of a Tarot de
+// Marseille deck.
+func NewTarotMajorArcanaDeck() *Deck {
+	return mustBuild(NewTarotDeckBuilder().Suits())
+}
+
+// NewTarotMinorArcanaDeck builds a deck of the 56 suited cards of a Tarot de
+// Marseille deck.
+func NewTarotMinorArcanaDeck() *Deck {
+	return mustBuild(NewTarotDeckBuilder().Trumps())
+}
@@ -0,0 +1,119 @@
+package cardware
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDeckBuilder_Presets(t *testing.T) {
+	tests := []struct {
+		name   string
+		d      *Deck
+		cards  int
+		first  string
+		last   string
+		values []string
+		colors []string
+	}{
+		{"piquet", NewPiquetDeck(), 32, "A♠", "K♣", []string{"A", "7", "8", "9", "T", "J", "Q", "K"}, []string{"B", "R"}},
+		{"euchre", NewEuchreDeck(), 24, "A♠", "K♣", []string{"A", "9", "T", "J", "Q", "K"}, []string{"B", "R"}},
+		{"french-knights", NewFrenchDeckWithKnights(), 56, "A♠", "K♣", []string{"A", "2", "3", "4", "5", "6", "7", "8", "9", "T", "J", "N", "Q", "K"}, []string{"B", "R"}},
+		{"tarot-major", NewTarotMajorArcanaDeck(), 22, "0", "XXI", []string{}, []string{}},
+		{"tarot-minor", NewTarotMinorArcanaDeck(), 56, "A♣", "K⛤", runeStrings(TarotDeMarseilleValues), []string{"L", "R"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.MaxDraws(); got != tt.cards {
+				t.Fatalf("MaxDraws() = %v, want %v", got, tt.cards)
+			}
+			if got, err := tt.d.Translate(rune(tt.d.Card(0))); err != nil || got != tt.first {
+				t.Errorf("Translate(first card) = %v, %v, want %v", got, err, tt.first)
+			}
+			if got, err := tt.d.Translate(rune(tt.d.Card(tt.cards - 1))); err != nil || got != tt.last {
+				t.Errorf("Translate(last card) = %v, %v, want %v", got, err, tt.last)
+			}
+			if got := tt.d.Values(); !reflect.DeepEqual(got, tt.values) {
+				t.Errorf("Values() = %v, want %v", got, tt.values)
+			}
+			if got := tt.d.Colors(); !reflect.DeepEqual(got, tt.colors) {
+				t.Errorf("Colors() = %v, want %v", got, tt.colors)
+			}
+			for i := 0; i < tt.cards; i++ {
+				name, err := tt.d.Translate(rune(tt.d.Card(i)))
+				if err != nil {
+					t.Fatalf("Translate(%c) error = %v", tt.d.Card(i), err)
+				}
+				if c, err := tt.d.Parse(name); err != nil || c != rune(tt.d.Card(i)) {
+					t.Errorf("Parse(%s) = %c, %v, want %c", name, c, err, tt.d.Card(i))
+				}
+			}
+		})
+	}
+}
+
+func TestDeckBuilder_Build(t *testing.T) {
+	b := NewFrenchDeckBuilder()
+	if err := b.ParseSuits("S♡"); err != nil {
+		t.Fatalf("ParseSuits() error = %v", err)
+	}
+	if err := b.ParseRanks("a, 10, k"); err != nil {
+		t.Fatalf("ParseRanks() error = %v", err)
+	}
+	if err := b.ParseTrumps("21"); err != nil {
+		t.Fatalf("ParseTrumps() error = %v", err)
+	}
+	d, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	want := []string{"A♠", "T♠", "K♠", "A♡", "T♡", "K♡", "XXI"}
+	for i, w := range want {
+		if got, err := d.Translate(rune(d.Card(i))); err != nil || got != w {
+			t.Errorf("Translate(card %d) = %v, %v, want %v", i, got, err, w)
+		}
+	}
+	if d.MaxDraws() != len(want) {
+		t.Errorf("MaxDraws() = %v, want %v", d.MaxDraws(), len(want))
+	}
+	if _, err := d.Translate(KingOfClubs); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("Translate(KingOfClubs) error = %v, want %v", err, ErrOutOfBounds)
+	}
+	if got := d.Colors(); !reflect.DeepEqual(got, []string{"B", "R"}) {
+		t.Errorf("Colors() = %v, want [B R]", got)
+	}
+}
+
+func TestDeckBuilder_Invalid(t *testing.T) {
+	if err := NewFrenchDeckBuilder().ParseSuits("SX"); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("ParseSuits() error = %v, want %v", err, ErrOutOfBounds)
+	}
+	if err := NewTarotDeckBuilder().ParseSuits("H"); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("ParseSuits() error = %v, want %v", err, ErrOutOfBounds)
+	}
+	if err := NewFrenchDeckBuilder().ParseRanks("A,1"); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("ParseRanks() error = %v, want %v", err, ErrOutOfBounds)
+	}
+	for _, s := range []string{"22", "-1", "5-3", "1,,2", "x", "0-x"} {
+		if err := NewTarotDeckBuilder().ParseTrumps(s); !errors.Is(err, ErrOutOfBounds) {
+			t.Errorf("ParseTrumps(%s) error = %v, want %v", s, err, ErrOutOfBounds)
+		}
+	}
+	tests := []struct {
+		name string
+		b    *DeckBuilder
+		want error
+	}{
+		{"suit", NewFrenchDeckBuilder().Suits(NoSuit), ErrOutOfBounds},
+		{"rank", NewFrenchDeckBuilder().Ranks(King + 1), ErrOutOfBounds},
+		{"trump", NewTarotDeckBuilder().Trumps(22), ErrOutOfBounds},
+		{"empty", NewTarotDeckBuilder().Suits().Trumps(), ErrEmptyDeck},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.b.Build(); !errors.Is(err, tt.want) {
+				t.Errorf("Build() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package cardware
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Cards are runes of the Unicode Playing Cards block. Each suit has a row of
+// sixteen runes, starting with the ace of spades, holding the ace through king
+// (with a knight between the jack and the queen) and then a joker. The trumps
+// of a tarot deck follow the four suits.
+
+// Suit is the suit of a card, in the order of the rows of the Unicode Playing
+// Cards block. Tarot de Marseille suits share the rows of the French suits, in
+// the order of TarotDeMarseilleSuits.
+type Suit int
+
+// Suits of cards.
+const (
+	NoSuit Suit = iota - 1
+	Spades
+	Hearts
+	Diamonds
+	Clubs
+)
+
+// Rank is the rank of a suited card, counting the ace as one.
+type Rank int
+
+// Ranks of suited cards. Only tarot decks have knights.
+const (
+	NoRank Rank = iota
+	Ace
+	Two
+	Three
+	Four
+	Five
+	Six
+	Seven
+	Eight
+	Nine
+	Ten
+	Jack
+	Knight
+	Queen
+	King
+)
+
+// Color is the color of a French card.
+type Color int
+
+// Colors of French cards.
+const (
+	NoColor Color = iota
+	Black
+	Red
+	White
+)
+
+// String returns the name of the color as used in FrenchColors, or "W" for the
+// white joker.
+func (c Color) String() string {
+	switch c {
+	case Black:
+		return string(FrenchColors[0])
+	case Red:
+		return string(FrenchColors[1])
+	case White:
+		return "W"
+	}
+	return ""
+}
+
+// IsSuited reports whether the card is an ace through king of one of the four suits.
+func (c Card) IsSuited() bool {
+	return c >= AceOfSpades && c <= KingOfClubs && (c-AceOfSpades)%16 < 14
+}
+
+// Suit returns the suit of a suited card, or NoSuit.
+func (c Card) Suit() Suit {
+	if !c.IsSuited() {
+		return NoSuit
+	}
+	return Suit((c - AceOfSpades) / 16)
+}
+
+// Rank returns the rank of a suited card, or NoRank.
+func (c Card) Rank() Rank {
+	if !c.IsSuited() {
+		return NoRank
+	}
+	return Rank((c-AceOfSpades)%16) + Ace
+}
+
+// IsJoker reports whether the card is one of FrenchJokers.
+func (c Card) IsJoker() bool {
+	return c == RedJoker || c == BlackJoker || c == WhiteJoker
+}
+
+// Color returns the color of a suited card or joker in a French deck: spades
+// and clubs are black, hearts and diamonds are red. Trumps have no color.
+func (c Card) Color() Color {
+	switch {
+	case c == RedJoker:
+		return Red
+	case c == BlackJoker:
+		return Black
+	case c == WhiteJoker:
+		return White
+	case c.Suit() == Spades || c.Suit() == Clubs:
+		return Black
+	case c.Suit() == Hearts || c.Suit() == Diamonds:
+		return Red
+	}
+	return NoColor
+}
+
+// IsTrump reports whether the card is one of the 22 trumps of a tarot deck.
+func (c Card) IsTrump() bool {
+	return c >= TheFool && c <= TheWorld
+}
+
+// Trump returns the number of a trump card, from 0 for the fool to 21 for the
+// world, or -1 if the card is not a trump.
+func (c Card) Trump() int {
+	if !c.IsTrump() {
+		return -1
+	}
+	return int(c - TheFool)
+}
+
+// NewCard returns the suited card of the given rank and suit.
+func NewCard(rank Rank, suit Suit) (Card, error) {
+	if rank < Ace || rank > King || suit < Spades || suit > Clubs {
+		return 0, fmt.Errorf("rank %d of suit %d is %w", rank, suit, ErrOutOfBounds)
+	}
+	return AceOfSpades + Card(suit)*16 + Card(rank-Ace), nil
+}
+
+// ParseCard parses the name of a French card, such as "A♠" or "10♡", into a
+// card. Suits may be typed with FrenchSuitsASCII, as in "AS" or "10H", values
+// may be any of TarotDeMarseilleValues, so "N" names a knight, and names are not
+// case sensitive. Jokers are named by FrenchJokerNames and trumps by
+// TarotDeMarseilleTrumps. Use Deck.Parse to parse the names of cards in decks
+// with other suits.
+func ParseCard(name string) (Card, error) {
+	return parseCard(name, FrenchSuits, FrenchSuitsASCII)
+}
+
+// parseTarotDeMarseille parses the name of a Tarot de Marseille card, as
+// ParseCard does for French cards.
+func parseTarotDeMarseille(name string) (Card, error) {
+	return parseCard(name, TarotDeMarseilleSuits, TarotDeMarseilleSuitsASCII)
+}
+
+// parseCard parses the name of a card whose suits are named by suits, or typed
+// with ascii, in the order of Suit.
+func parseCard(name string, suits, ascii []rune) (Card, error) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	for i, j := range FrenchJokerNames {
+		if name == j {
+			return Card(FrenchJokers[i]), nil
+		}
+	}
+	for i, t := range TarotDeMarseilleTrumps {
+		if name == t {
+			return TheFool + Card(i), nil
+		}
+	}
+	if strings.HasPrefix(name, "10") {
+		name = "T" + name[2:]
+	}
+	runes := []rune(name)
+	if len(runes) != 2 {
+		return 0, fmt.Errorf("card '%s' is %w", name, ErrOutOfBounds)
+	}
+	rank := NoRank
+	for i, v := range TarotDeMarseilleValues {
+		if runes[0] == v {
+			rank = Ace + Rank(i)
+		}
+	}
+	suit := NoSuit
+	for i := range suits {
+		if runes[1] == suits[i] || runes[1] == ascii[i] {
+			suit = Spades + Suit(i)
+		}
+	}
+	if rank == NoRank || suit == NoSuit {
+		return 0, fmt.Errorf("card '%s' is %w", name, ErrOutOfBounds)
+	}
+	return NewCard(rank, suit)
+}
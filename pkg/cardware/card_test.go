@@ -0,0 +1,98 @@
+package cardware
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCard_Accessors(t *testing.T) {
+	tests := []struct {
+		name  string
+		c     Card
+		suit  Suit
+		rank  Rank
+		color Color
+		trump int
+	}{
+		{"ace-of-spades", AceOfSpades, Spades, Ace, Black, -1},
+		{"ten-of-hearts", '🂺', Hearts, Ten, Red, -1},
+		{"knight-of-diamonds", '🃌', Diamonds, Knight, Red, -1},
+		{"king-of-clubs", KingOfClubs, Clubs, King, Black, -1},
+		{"red-joker", RedJoker, NoSuit, NoRank, Red, -1},
+		{"white-joker", WhiteJoker, NoSuit, NoRank, White, -1},
+		{"the-fool", TheFool, NoSuit, NoRank, NoColor, 0},
+		{"the-world", TheWorld, NoSuit, NoRank, NoColor, 21},
+		{"letter", 'A', NoSuit, NoRank, NoColor, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.Suit(); got != tt.suit {
+				t.Errorf("Card.Suit() = %v, want %v", got, tt.suit)
+			}
+			if got := tt.c.Rank(); got != tt.rank {
+				t.Errorf("Card.Rank() = %v, want %v", got, tt.rank)
+			}
+			if got := tt.c.Color(); got != tt.color {
+				t.Errorf("Card.Color() = %v, want %v", got, tt.color)
+			}
+			if got := tt.c.Trump(); got != tt.trump {
+				t.Errorf("Card.Trump() = %v, want %v", got, tt.trump)
+			}
+			if got := tt.c.IsTrump(); got != (tt.trump >= 0) {
+				t.Errorf("Card.IsTrump() = %v, want %v", got, tt.trump >= 0)
+			}
+		})
+	}
+}
+
+func TestNewCard(t *testing.T) {
+	for _, r := range TarotDeMarseilleCards[:56] {
+		c := Card(r)
+		if got, err := NewCard(c.Rank(), c.Suit()); err != nil || got != c {
+			t.Errorf("NewCard(%v, %v) = %c, %v, want %c", c.Rank(), c.Suit(), got, err, c)
+		}
+	}
+	if _, err := NewCard(NoRank, Spades); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("NewCard(NoRank, Spades) error = %v, want %v", err, ErrOutOfBounds)
+	}
+}
+
+func TestParseCard(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Card
+		wantErr bool
+	}{
+		{"A♠", AceOfSpades, false},
+		{"as", AceOfSpades, false},
+		{"10H", '🂺', false},
+		{"T♡", '🂺', false},
+		{"N♢", '🃌', false},
+		{"KC", KingOfClubs, false},
+		{"RJ", RedJoker, false},
+		{"0", TheFool, false},
+		{"xxi", TheWorld, false},
+		{"1S", 0, true},
+		{"AX", 0, true},
+		{"A", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCard(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCard() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseCard() = %c, want %c", got, tt.want)
+			}
+		})
+	}
+
+	// every French card parses from its own name
+	for _, r := range FrenchCards {
+		name, _ := TranslateFrench(r)
+		if got, err := ParseCard(name); err != nil || got != Card(r) {
+			t.Errorf("ParseCard(%s) = %c, %v, want %c", name, got, err, r)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package cardware
+
+// Regional decks have no runes of their own in Unicode, so they are built as
+// custom decks, with runes assigned from CustomCardBase. Suits are named in the
+// deck's own language, and each suit may be typed with the ASCII initial of its
+// name. The initials are also the suits' colors, so that symbol tables printed
+// with a column per color stay aligned.
+
+// SpanishSuits are the suits of a Spanish deck: oros (coins), copas (cups),
+// espadas (swords), and bastos (clubs).
+var SpanishSuits = []string{"Oros", "Copas", "Espadas", "Bastos"}
+
+// SpanishSuitsASCII are the ASCII letters used in place of SpanishSuits.
+var SpanishSuitsASCII = []rune{'O', 'C', 'E', 'B'}
+
+// ItalianSuits are the suits of an Italian regional deck: denari (coins), coppe
+// (cups), spade (swords), and bastoni (clubs).
+var ItalianSuits = []string{"Denari", "Coppe", "Spade", "Bastoni"}
+
+// ItalianSuitsASCII are the ASCII letters used in place of ItalianSuits.
+var ItalianSuitsASCII = []rune{'D', 'C', 'S', 'B'}
+
+// GermanSuits are the suits of a German deck: Herz (hearts), Schellen (bells),
+// Laub (leaves), and Eichel (acorns).
+var GermanSuits = []string{"Herz", "Schellen", "Laub", "Eichel"}
+
+// GermanSuitsASCII are the ASCII letters used in place of GermanSuits.
+var GermanSuitsASCII = []rune{'H', 'S', 'L', 'E'}
+
+// SwissSuits are the suits of a Swiss Jass deck: Rosen (roses), Schellen
+// (bells), Schilten (shields), and Eicheln (acorns).
+var SwissSuits = []string{"Rosen", "Schellen", "Schilten", "Eicheln"}
+
+// SwissSuitsASCII are the ASCII letters used in place of SwissSuits. Schellen
+// is typed as B (for bells) to tell it apart from Schilten.
+var SwissSuitsASCII = []rune{'R', 'B', 'S', 'E'}
+
+// SpanishValues are the twelve values of cards for each suit in a 48-card
+// Spanish deck: one through nine, sota, caballo, and rey. The 40-card deck has
+// no eights or nines.
+var SpanishValues = []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "S", "C", "R"}
+
+// ItalianValues are the ten values of cards for each suit in an Italian
+// regional deck: asso, two through seven, fante, cavallo, and re.
+var ItalianValues = []string{"A", "2", "3", "4", "5", "6", "7", "F", "C", "R"}
+
+// GermanValues are the nine values of cards for each suit in a 36-card German
+// deck: six through ten, Unter, Ober, König, and Daus. The 32-card deck has no
+// sixes.
+var GermanValues = []string{"6", "7", "8", "9", "T", "U", "O", "K", "A"}
+
+// SwissValues are the nine values of cards for each suit in a Swiss Jass deck:
+// six through nine, Banner, Under, Ober, König, and Ass.
+var SwissValues = []string{"6", "7", "8", "9", "T", "U", "O", "K", "A"}
+
+// regionalDeck builds a deck of the given ranks of every suit. The definitions
+// of regional decks are fixed, so an error building one is a bug.
+func regionalDeck(name string, suits []string, ascii []rune, ranks []string) *Deck {
+	def := DeckDefinition{Name: name, Ranks: ranks}
+	for i, s := range suits {
+		a := string(ascii[i])
+		def.Suits = append(def.Suits, SuitDefinition{Name: s, ASCII: a, Color: a})
+	}
+	d, err := NewCustomDeck(def)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// NewSpanishDeck builds a 40-card Spanish deck with four Latin suits (oros,
+// copas, espadas, bastos) of ten values (one through seven, sota, caballo, rey).
+func NewSpanishDeck() *Deck {
+	ranks := append(append([]string(nil), SpanishValues[:7]...), SpanishValues[9:]...)
+	return regionalDeck("Spanish", SpanishSuits, SpanishSuitsASCII, ranks)
+}
+
+// NewSpanishDeck48 builds a 48-card Spanish deck with four Latin suits of
+// twelve values (one through nine, sota, caballo, rey).
+func NewSpanishDeck48() *Deck {
+	return regionalDeck("Spanish 48", SpanishSuits, SpanishSuitsASCII, SpanishValues)
+}
+
+// NewItalianDeck builds a 40-card Italian regional deck with four Latin suits
+// (denari, coppe, spade, bastoni) of ten values (asso, two through seven,
+// fante, cavallo, re).
+func NewItalianDeck() *Deck {
+	return regionalDeck("Italian", ItalianSuits, ItalianSuitsASCII, ItalianValues)
+}
+
+// NewGermanDeck builds a 32-card German deck with four German suits (Herz,
+// Schellen, Laub, Eichel) of eight values (seven through ten, Unter, Ober,
+// König, Daus).
+func NewGermanDeck() *Deck {
+	return regionalDeck("German", GermanSuits, GermanSuitsASCII, GermanValues[1:])
+}
+
+// NewGermanDeck36 builds a 36-card German deck with four German suits of nine
+// values (six through ten, Unter, Ober, König, Daus).
+func NewGermanDeck36() *Deck {
+	return regionalDeck("German 36", GermanSuits, GermanSuitsASCII, GermanValues)
+}
+
+// NewSwissJassDeck builds a 36-card Swiss Jass deck with four Swiss suits
+// (Rosen, Schellen, Schilten, Eicheln) of nine values (six through nine,
+// Banner, Under, Ober, König, Ass).
+func NewSwissJassDeck() *Deck {
+	return regionalDeck("Swiss Jass", SwissSuits, SwissSuitsASCII, SwissValues)
+}
@@ -0,0 +1,99 @@
+package cardware
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRegionalDecks_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		cards int
+		suits []string
+		ascii []rune
+	}{
+		{"spanish", 40, SpanishSuits, SpanishSuitsASCII},
+		{"spanish-48", 48, SpanishSuits, SpanishSuitsASCII},
+		{"italian", 40, ItalianSuits, ItalianSuitsASCII},
+		{"german", 32, GermanSuits, GermanSuitsASCII},
+		{"german-36", 36, GermanSuits, GermanSuitsASCII},
+		{"swiss", 36, SwissSuits, SwissSuitsASCII},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewRegisteredDeck(tt.name)
+			if err != nil {
+				t.Fatalf("NewRegisteredDeck(%s) error = %v", tt.name, err)
+			}
+			if got := d.MaxDraws(); got != tt.cards {
+				t.Fatalf("MaxDraws() = %v, want %v", got, tt.cards)
+			}
+			if got := d.Colors(); !reflect.DeepEqual(got, runeStrings(tt.ascii)) {
+				t.Errorf("Colors() = %v, want %v", got, runeStrings(tt.ascii))
+			}
+			values := d.Values()
+			for i := 0; i < tt.cards; i++ {
+				c := rune(d.Card(i))
+				value, suit := values[i%len(values)], i/len(values)
+				name, err := d.Translate(c)
+				if err != nil {
+					t.Fatalf("Translate(%c) error = %v", c, err)
+				}
+				if want := value + tt.suits[suit]; name != want {
+					t.Errorf("Translate(%c) = %s, want %s", c, name, want)
+				}
+				for _, typed := range []string{name, value + string(tt.ascii[suit])} {
+					if got, err := d.Parse(typed); err != nil || got != c {
+						t.Errorf("Parse(%s) = %c, %v, want %c", typed, got, err, c)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRegionalDecks_Parse(t *testing.T) {
+	tests := []struct {
+		name  string
+		d     *Deck
+		typed string
+		want  string
+	}{
+		{"spanish-caballo-copas", NewSpanishDeck(), "CC", "CCopas"},
+		{"italian-cavallo-coppe", NewItalianDeck(), "cc", "CCoppe"},
+		{"spanish-sota-espadas", NewSpanishDeck(), "SE", "SEspadas"},
+		{"italian-no-sota", NewItalianDeck(), "SE", ""},
+		{"italian-asso-spade", NewItalianDeck(), "AS", "ASpade"},
+		{"german-daus-schellen", NewGermanDeck(), "AS", "ASchellen"},
+		{"spanish-no-ace", NewSpanishDeck(), "AS", ""},
+		{"german-unter-schellen", NewGermanDeck(), "US", "USchellen"},
+		{"swiss-under-schilten", NewSwissJassDeck(), "US", "USchilten"},
+		{"swiss-under-schellen", NewSwissJassDeck(), "UB", "USchellen"},
+		{"german-no-b", NewGermanDeck(), "UB", ""},
+		{"german-ten", NewGermanDeck(), "10H", "THerz"},
+		{"swiss-ten", NewSwissJassDeck(), "10s", "TSchilten"},
+		{"spanish-no-ten", NewSpanishDeck48(), "10O", ""},
+		{"german-no-six", NewGermanDeck(), "6E", ""},
+		{"german-36-six", NewGermanDeck36(), "6E", "6Eichel"},
+		{"spanish-no-eight", NewSpanishDeck(), "8B", ""},
+		{"spanish-48-eight", NewSpanishDeck48(), "8B", "8Bastos"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := tt.d.Parse(tt.typed)
+			if tt.want == "" {
+				if !errors.Is(err, ErrOutOfBounds) {
+					t.Errorf("Parse(%s) = %c, %v, want %v", tt.typed, r, err, ErrOutOfBounds)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%s) error = %v", tt.typed, err)
+			}
+			if got, _ := tt.d.Translate(r); got != tt.want {
+				t.Errorf("Parse(%s) = %s, want %s", tt.typed, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package cardware
+
+// CoinFaces are the labels of the two sides of a coin (heads and tails).
+var CoinFaces = []string{"H", "T"}
+
+// CoinBag represents a collection of coins. The coins are distinguishable: each
+// flip counts separately, so n coins have 2^n distinct outcomes whether they are
+// flipped one at a time or several distinguishable coins are flipped together.
+//
+// A CoinBag is a DiceBag of two-sided dice labeled with CoinFaces, so it can be
+// used anywhere a DiceBag can.
+type CoinBag struct {
+	DiceBag
+}
+
+// NewCoinBag creates a new bag of coins.
+func NewCoinBag(coins int) *CoinBag {
+	labels := make([][]string, coins)
+	for i := range labels {
+		labels[i] = CoinFaces
+	}
+	return &CoinBag{DiceBag: *NewLabeledDiceBag(labels)}
+}
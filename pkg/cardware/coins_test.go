@@ -0,0 +1,28 @@
+package cardware
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestCoinBag(t *testing.T) {
+	var ro RandomObject = NewCoinBag(3)
+	if got, err := ro.CountDistinctOutcomes(3); err != nil || got.Cmp(big.NewInt(8)) != 0 {
+		t.Errorf("CoinBag.CountDistinctOutcomes() = %v, want 8", got)
+	}
+	o, err := ro.Outcome(3, big.NewInt(0))
+	if err != nil {
+		t.Fatalf("CoinBag.Outcome() error = %v", err)
+	}
+	want := []string{"[H]", "[H]", "[H]"}
+	for i, r := range o {
+		if got, err := ro.Translate(r); err != nil || got != want[i] {
+			t.Errorf("CoinBag.Translate() = %v, %v, want %v", got, err, want[i])
+		}
+	}
+
+	c := NewCombinedFrom(&NewCoinBag(1).DiceBag, NewStandardFrenchDeck())
+	if got, err := c.CountDistinctOutcomes(2); err != nil || got.Cmp(big.NewInt(104)) != 0 {
+		t.Errorf("Combined.CountDistinctOutcomes() = %v, want 104", got)
+	}
+}
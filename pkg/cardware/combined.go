@@ -1,63 +1,113 @@
 package cardware
 
-import "math/big"
+import (
+	"math/big"
+	"math/rand"
+)
 
-// Combined embeds a DiceBag and a Deck.
+// Combined embeds a DiceBag and a Deck. It is a Composite of the dice followed
+// by the cards.
 type Combined struct {
 	RandomObject
 	DiceBag
 	Deck
-	lastDice []rune
+	composite *Composite
 }
 
 // NewCombined creates a new DiceBag and Deck simultaneously
 func NewCombined(dice []int) *Combined {
-	db := NewDiceBag(dice)
-	deck := NewStandardFrenchDeck()
+	return NewCombinedFrom(NewDiceBag(dice), NewStandardFrenchDeck())
+}
+
+// NewCombinedFrom combines the given DiceBag and Deck.
+func NewCombinedFrom(db *DiceBag, deck *Deck) *Combined {
 	return &Combined{DiceBag: *db, Deck: *deck}
 }
 
-func (c *Combined) splitDraws(k int) (int, int) {
-	kdice := k
-	kcards := 0
-	if k > c.DiceBag.MaxDraws() {
-		kdice = c.DiceBag.MaxDraws()
-		kcards = k - kdice
+func (c *Combined) objects() *Composite {
+	if c.composite == nil {
+		c.composite = NewComposite(&c.DiceBag, &c.Deck)
 	}
-	return kdice, kcards
+	return c.composite
 }
 
 // MaxDraws implements RandomObject interface.
 func (c *Combined) MaxDraws() int {
-	return c.DiceBag.MaxDraws() * c.Deck.MaxDraws()
+	return c.objects().MaxDraws()
 }
 
 // CountDistinctOutcomes implements RandomObject interface.
-func (c *Combined) CountDistinctOutcomes(k int) *big.Int {
-	kdice, kcards := c.splitDraws(k)
-	out := c.DiceBag.CountDistinctOutcomes(kdice)
-	return out.Mul(out, c.Deck.CountDistinctOutcomes(kcards))
-}
-
-// NextOutcome implements RandomObject interface.
-func (c *Combined) NextOutcome(k int) []rune {
-	kdice, kcards := c.splitDraws(k)
-	c.lastDice = c.DiceBag.NextOutcome(kdice)
-	cards := c.Deck.NextOutcome(kcards)
-	if kcards > 0 && cards == nil {
-		// restart from the first card by drawing again
-		cards = c.Deck.NextOutcome(kcards)
-	}
-	if c.lastDice == nil {
-		return nil // nothing left to draw.
-	}
-	return append(c.lastDice, cards...)
+func (c *Combined) CountDistinctOutcomes(k int) (*big.Int, error) {
+	return c.objects().CountDistinctOutcomes(k)
+}
+
+// NextOutcome returns the next of the distinct outcomes of k draws, or nil once
+// every outcome has been returned. It returns an error if k draws cannot be
+// made.
+//
+// Deprecated: Use Outcomes instead.
+func (c *Combined) NextOutcome(k int) ([]rune, error) {
+	return c.objects().NextOutcome(k)
+}
+
+// Outcomes implements RandomObject interface.
+func (c *Combined) Outcomes(k int) (OutcomeIterator, error) {
+	return c.objects().Outcomes(k)
 }
 
-// Translate implements RandomObject interface.
+// RandomOutcome implements RandomObject interface.
+func (c *Combined) RandomOutcome(k int, src rand.Source) ([]rune, error) {
+	return c.objects().RandomOutcome(k, src)
+}
+
+// Outcome implements RandomObject interface.
+func (c *Combined) Outcome(k int, index *big.Int) ([]rune, error) {
+	return c.objects().Outcome(k, index)
+}
+
+// Index implements RandomObject interface.
+func (c *Combined) Index(outcome []rune) (*big.Int, error) {
+	return c.objects().Index(outcome)
+}
+
+// Weight returns the number of ways the outcome can be drawn. Dice only count
+// and enumerate outcomes that are equally likely, so only duplicate cards in the
+// deck make some outcomes more likely than others.
+func (c *Combined) Weight(outcome []rune) *big.Int {
+	return c.objects().Weight(outcome)
+}
+
+// uniform implements weigher interface.
+func (c *Combined) uniform() bool {
+	return c.objects().uniform()
+}
+
+// Shuffle implements Shuffler interface. It shuffles both the dice and the deck.
+func (c *Combined) Shuffle(src rand.Source) {
+	c.objects().Shuffle(src)
+}
+
+// Translate implements RandomObject interface. See Composite.Translate.
 func (c *Combined) Translate(r rune) (string, error) {
-	if r < AceOfSpades {
-		return c.DiceBag.Translate(r)
-	}
-	return c.Deck.Translate(r)
+	return c.objects().Translate(r)
+}
+
+// Tag implements Tagger interface. Rolls of the dice are tagged with source
+// zero and cards with source one.
+func (c *Combined) Tag(outcome []rune) ([]Draw, error) {
+	return c.objects().Tag(outcome)
+}
+
+// TranslateDraw implements Tagger interface.
+func (c *Combined) TranslateDraw(d Draw) (string, error) {
+	return c.objects().TranslateDraw(d)
+}
+
+// snapshot implements snapshotter interface.
+func (c *Combined) snapshot() RandomObject {
+	return c.objects().snapshot()
+}
+
+func (c *Combined) sources() []RandomObject {
+	return c.objects().sources()
 }
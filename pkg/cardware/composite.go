@@ -0,0 +1,420 @@
+package cardware
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+)
+
+// Composite combines an ordered list of RandomObjects, such as two decks, a
+// tarot deck and dice, or coins and cards. Draws are taken from each object in
+// turn: all of the draws from the first object are made before any are made
+// from the second, and so on.
+type Composite struct {
+	RandomObject
+	objects []RandomObject
+	draws   int
+	next    OutcomeIterator
+}
+
+// NewComposite combines the given RandomObjects in order.
+func NewComposite(objects ...RandomObject) *Composite {
+	o := make([]RandomObject, len(objects))
+	copy(o, objects)
+	return &Composite{objects: o}
+}
+
+// Objects returns the combined RandomObjects in order.
+func (c *Composite) Objects() []RandomObject {
+	o := make([]RandomObject, len(c.objects))
+	copy(o, c.objects)
+	return o
+}
+
+// split divides k draws among the objects, filling each in turn.
+func (c *Composite) split(k int) []int {
+	ks := make([]int, len(c.objects))
+	for i, o := range c.objects {
+		ks[i] = o.MaxDraws()
+		if ks[i] > k {
+			ks[i] = k
+		}
+		k -= ks[i]
+	}
+	return ks
+}
+
+// MaxDraws implements RandomObject interface. It is at most UnlimitedDraws.
+func (c *Composite) MaxDraws() int {
+	n := 0
+	for _, o := range c.objects {
+		m := o.MaxDraws()
+		if m >= UnlimitedDraws-n {
+			return UnlimitedDraws
+		}
+		n += m
+	}
+	return n
+}
+
+// CountDistinctOutcomes implements RandomObject interface.
+func (c *Composite) CountDistinctOutcomes(k int) (*big.Int, error) {
+	if err := checkDraws(k, c.MaxDraws()); err != nil {
+		return nil, err
+	}
+	n := big.NewInt(1)
+	for i, ki := range c.split(k) {
+		ni, err := c.objects[i].CountDistinctOutcomes(ki)
+		if err != nil {
+			return nil, err
+		}
+		n.Mul(n, ni)
+	}
+	return n, nil
+}
+
+// NextOutcome returns the next of the distinct outcomes of k draws, or nil once
+// every outcome has been returned or if k is zero. It returns an error if k
+// draws cannot be made.
+//
+// Deprecated: NextOutcome keeps its place in the enumeration in the composite,
+// and restarts whenever k changes. Use Outcomes instead.
+func (c *Composite) NextOutcome(k int) ([]rune, error) {
+	if err := checkDraws(k, c.MaxDraws()); err != nil {
+		return nil, err
+	}
+	if k == 0 {
+		return nil, nil
+	}
+	if c.next == nil || k != c.draws {
+		next, err := c.Outcomes(k)
+		if err != nil {
+			return nil, err
+		}
+		c.draws = k
+		c.next = next
+	}
+	out := c.next.Next()
+	if out == nil {
+		c.next = nil
+	}
+	return out, nil
+}
+
+// compositeIterator enumerates the outcomes of a Composite like an odometer:
+// the last object's outcomes change fastest, and the first object's change
+// slowest.
+type compositeIterator struct {
+	ks      []int
+	wheels  []*wheel
+	current [][]rune
+	begun   bool
+	done    bool
+}
+
+// wheel steps through the outcomes of one object of a composite. The object is
+// a snapshot taken when the composite's iterator was created, so the wheel can
+// start its outcomes over each time it rolls over without keeping them, and
+// without being disturbed if the composite's objects are shuffled.
+type wheel struct {
+	object RandomObject
+	k      int
+	it     OutcomeIterator
+}
+
+// next returns the wheel's next outcome, or nil once it has rolled over.
+func (w *wheel) next() []rune {
+	return w.it.Next()
+}
+
+// restart starts the wheel's outcomes over.
+func (w *wheel) restart() {
+	// the snapshot's outcomes were already enumerated for k draws, so this
+	// cannot fail
+	w.it, _ = w.object.Outcomes(w.k)
+}
+
+// snapshotter is implemented by RandomObjects that can copy their own state.
+type snapshotter interface {
+	// snapshot returns a copy of the object that later changes to the object,
+	// such as shuffling it, do not disturb.
+	snapshot() RandomObject
+}
+
+// snapshot returns a copy of ro if ro can make one, or ro itself.
+func snapshot(ro RandomObject) RandomObject {
+	if s, ok := ro.(snapshotter); ok {
+		return s.snapshot()
+	}
+	return ro
+}
+
+// snapshot implements snapshotter interface.
+func (c *Composite) snapshot() RandomObject {
+	o := make([]RandomObject, len(c.objects))
+	for i, obj := range c.objects {
+		o[i] = snapshot(obj)
+	}
+	return &Composite{objects: o}
+}
+
+// Outcomes implements RandomObject interface. Objects from which no draws are
+// made are skipped. A snapshot of every object is taken when Outcomes is
+// called, and each time the objects before it advance, an object's outcomes
+// are enumerated again from its snapshot. Shuffling the objects therefore
+// does not disturb the iterator, unless they are RandomObjects from outside
+// this package, which are enumerated as they are.
+func (c *Composite) Outcomes(k int) (OutcomeIterator, error) {
+	if err := checkDraws(k, c.MaxDraws()); err != nil {
+		return nil, err
+	}
+	it := &compositeIterator{
+		ks:      c.split(k),
+		wheels:  make([]*wheel, len(c.objects)),
+		current: make([][]rune, len(c.objects)),
+	}
+	for i, o := range c.objects {
+		if it.ks[i] == 0 {
+			continue
+		}
+		s := snapshot(o)
+		oi, err := s.Outcomes(it.ks[i])
+		if err != nil {
+			return nil, err
+		}
+		it.wheels[i] = &wheel{object: s, k: it.ks[i], it: oi}
+	}
+	return it, nil
+}
+
+// Next implements OutcomeIterator interface.
+func (it *compositeIterator) Next() []rune {
+	if it.done {
+		return nil
+	}
+	if !it.begun {
+		it.begun = true
+		for i, w := range it.wheels {
+			if w == nil {
+				continue
+			}
+			if it.current[i] = w.next(); it.current[i] == nil {
+				it.done = true
+				return nil
+			}
+		}
+		return it.outcome()
+	}
+	for i := len(it.wheels) - 1; i >= 0; i-- {
+		if it.wheels[i] == nil {
+			continue
+		}
+		next := it.wheels[i].next()
+		if next == nil {
+			// this wheel has rolled over: advance the one before
+			continue
+		}
+		it.current[i] = next
+		// restart the wheels that rolled over
+		for j := i + 1; j < len(it.wheels); j++ {
+			if it.wheels[j] != nil {
+				it.wheels[j].restart()
+				it.current[j] = it.wheels[j].next()
+			}
+		}
+		return it.outcome()
+	}
+	it.done = true
+	return nil
+}
+
+func (it *compositeIterator) outcome() []rune {
+	out := make([]rune, 0)
+	for _, o := range it.current {
+		out = append(out, o...)
+	}
+	return out
+}
+
+// RandomOutcome implements RandomObject interface.
+func (c *Composite) RandomOutcome(k int, src rand.Source) ([]rune, error) {
+	if err := checkDraws(k, c.MaxDraws()); err != nil {
+		return nil, err
+	}
+	out := make([]rune, 0, k)
+	for i, ki := range c.split(k) {
+		o, err := c.objects[i].RandomOutcome(ki, src)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, o...)
+	}
+	return out, nil
+}
+
+// Outcome implements RandomObject interface.
+func (c *Composite) Outcome(k int, index *big.Int) ([]rune, error) {
+	n, err := c.CountDistinctOutcomes(k)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkIndex(index, n); err != nil {
+		return nil, err
+	}
+	ks := c.split(k)
+	idx := new(big.Int).Set(index)
+	parts := make([]*big.Int, len(c.objects))
+	r := new(big.Int)
+	// the last object's index is the least significant
+	for i := len(c.objects) - 1; i >= 0; i-- {
+		ni, err := c.objects[i].CountDistinctOutcomes(ks[i])
+		if err != nil {
+			return nil, err
+		}
+		idx.QuoRem(idx, ni, r)
+		parts[i] = new(big.Int).Set(r)
+	}
+	out := make([]rune, 0, k)
+	for i, o := range c.objects {
+		oi, err := o.Outcome(ks[i], parts[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, oi...)
+	}
+	return out, nil
+}
+
+// Index implements RandomObject interface.
+func (c *Composite) Index(outcome []rune) (*big.Int, error) {
+	if len(outcome) > c.MaxDraws() {
+		return nil, fmt.Errorf("outcome of %d draws is too long: %w", len(outcome), ErrTooManyDraws)
+	}
+	idx := big.NewInt(0)
+	start := 0
+	for i, ki := range c.split(len(outcome)) {
+		n, err := c.objects[i].Index(outcome[start : start+ki])
+		if err != nil {
+			return nil, err
+		}
+		ni, err := c.objects[i].CountDistinctOutcomes(ki)
+		if err != nil {
+			return nil, err
+		}
+		idx.Mul(idx, ni)
+		idx.Add(idx, n)
+		start += ki
+	}
+	return idx, nil
+}
+
+// Weight returns the number of ways the outcome can be drawn, the product of the
+// number of ways each object's part of it can be drawn. Objects other than decks
+// draw every distinct outcome in only one way.
+func (c *Composite) Weight(outcome []rune) *big.Int {
+	w := big.NewInt(1)
+	for i, k := range c.split(len(outcome)) {
+		if o, ok := c.objects[i].(weigher); ok {
+			w.Mul(w, o.Weight(outcome[:k]))
+		}
+		outcome = outcome[k:]
+	}
+	return w
+}
+
+// uniform implements weigher interface.
+func (c *Composite) uniform() bool {
+	for _, o := range c.objects {
+		if w, ok := o.(weigher); ok && !w.uniform() {
+			return false
+		}
+	}
+	return true
+}
+
+// Shuffle implements Shuffler interface. It shuffles every object that is a
+// Shuffler and restarts enumeration.
+func (c *Composite) Shuffle(src rand.Source) {
+	for _, o := range c.objects {
+		if s, ok := o.(Shuffler); ok {
+			s.Shuffle(src)
+		}
+	}
+	c.next = nil
+}
+
+// sources returns the objects of the composite, with the objects of any nested
+// Composite in its place. Draws fill the sources in order just as they fill the
+// objects, so the sources of an outcome's runes are found by splitting it among
+// them.
+func (c *Composite) sources() []RandomObject {
+	out := make([]RandomObject, 0, len(c.objects))
+	for _, o := range c.objects {
+		if n, ok := o.(nester); ok {
+			out = append(out, n.sources()...)
+			continue
+		}
+		out = append(out, o)
+	}
+	return out
+}
+
+// nester is implemented by RandomObjects made up of other RandomObjects.
+type nester interface {
+	sources() []RandomObject
+}
+
+// Translate implements RandomObject interface. A rune on its own does not say
+// which object drew it, so Translate returns an error wrapping ErrAmbiguous if
+// the objects that can translate it give it different names, as when a die
+// face and a card share a rune. Use TranslateOutcome or TranslateDraw to
+// translate each rune with the object that drew it.
+func (c *Composite) Translate(r rune) (string, error) {
+	var found string
+	n := 0
+	for _, o := range c.sources() {
+		name, err := o.Translate(r)
+		if err != nil {
+			continue
+		}
+		if n > 0 && name != found {
+			return "", fmt.Errorf("rune '%c' is both %s and %s: %w", r, found, name, ErrAmbiguous)
+		}
+		found = name
+		n++
+	}
+	if n == 0 {
+		return "", fmt.Errorf("rune '%c' is %w", r, ErrOutOfBounds)
+	}
+	return found, nil
+}
+
+// Tag implements Tagger interface.
+func (c *Composite) Tag(outcome []rune) ([]Draw, error) {
+	if len(outcome) > c.MaxDraws() {
+		return nil, fmt.Errorf("outcome of %d draws is too long: %w", len(outcome), ErrTooManyDraws)
+	}
+	sources := c.sources()
+	draws := make([]Draw, 0, len(outcome))
+	k := len(outcome)
+	for i, o := range sources {
+		ki := o.MaxDraws()
+		if ki > k {
+			ki = k
+		}
+		k -= ki
+		for j := 0; j < ki; j++ {
+			draws = append(draws, Draw{Object: i, Value: outcome[len(draws)]})
+		}
+	}
+	return draws, nil
+}
+
+// TranslateDraw implements Tagger interface.
+func (c *Composite) TranslateDraw(d Draw) (string, error) {
+	sources := c.sources()
+	if d.Object < 0 || d.Object >= len(sources) {
+		return "", fmt.Errorf("source %d is %w", d.Object, ErrOutOfBounds)
+	}
+	return sources[d.Object].Translate(d.Value)
+}
@@ -0,0 +1,136 @@
+package cardware
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestComposite_NextOutcome(t *testing.T) {
+	tests := []struct {
+		name  string
+		c     *Composite
+		k     int
+		max   int
+		count int64
+	}{
+		{
+			"dice",
+			NewComposite(NewDiceBag([]int{2}), NewDiceBag([]int{3})),
+			2,
+			2,
+			6,
+		},
+		{
+			"coin-and-cards",
+			NewComposite(NewCoinBag(1), NewStandardFrenchDeck()),
+			3,
+			53,
+			2 * 52 * 51,
+		},
+		{
+			"two-decks",
+			NewComposite(NewStandardFrenchDeck(), NewTarotDeMarseilleDeck()),
+			53,
+			130,
+			0, // not enumerated
+		},
+		{
+			"first-only",
+			NewComposite(NewDiceBag([]int{4, 4}), NewStandardFrenchDeck()),
+			1,
+			54,
+			4,
+		},
+		{
+			"zero",
+			NewComposite(NewDiceBag([]int{4}), NewStandardFrenchDeck()),
+			0,
+			53,
+			1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.MaxDraws(); got != tt.max {
+				t.Errorf("Composite.MaxDraws() = %v, want %v", got, tt.max)
+			}
+			if tt.count == 0 {
+				return
+			}
+			if got, err := tt.c.CountDistinctOutcomes(tt.k); err != nil || got.Cmp(big.NewInt(tt.count)) != 0 {
+				t.Errorf("Composite.CountDistinctOutcomes() = %v, want %v", got, tt.count)
+			}
+			if tt.k == 0 {
+				if got, err := tt.c.NextOutcome(0); got != nil || err != nil {
+					t.Errorf("Composite.NextOutcome() = %v, %v, want nil, nil", got, err)
+				}
+				return
+			}
+			seen := make(map[string]bool)
+			for o, _ := tt.c.NextOutcome(tt.k); o != nil; o, _ = tt.c.NextOutcome(tt.k) {
+				if len(o) != tt.k {
+					t.Fatalf("Composite.NextOutcome() = %v, want %d draws", o, tt.k)
+				}
+				if seen[string(o)] {
+					t.Fatalf("Composite.NextOutcome() repeated %v", o)
+				}
+				seen[string(o)] = true
+			}
+			if int64(len(seen)) != tt.count {
+				t.Errorf("Composite.NextOutcome() enumerated %d outcomes, want %d", len(seen), tt.count)
+			}
+		})
+	}
+}
+
+func TestComposite_Odometer(t *testing.T) {
+	c := NewComposite(NewDiceBag([]int{2}), NewDiceBag([]int{3}))
+	want := [][]rune{{0, 0}, {0, 1}, {0, 2}, {1, 0}, {1, 1}, {1, 2}}
+	for i, w := range want {
+		if got, _ := c.NextOutcome(2); !reflect.DeepEqual(got, w) {
+			t.Errorf("Composite.NextOutcome() #%d = %v, want %v", i, got, w)
+		}
+	}
+	if got, _ := c.NextOutcome(2); got != nil {
+		t.Errorf("Composite.NextOutcome() = %v, want nil", got)
+	}
+	// enumeration restarts after the last outcome
+	if got, _ := c.NextOutcome(2); !reflect.DeepEqual(got, want[0]) {
+		t.Errorf("Composite.NextOutcome() = %v, want %v", got, want[0])
+	}
+	if got, err := c.NextOutcome(3); got != nil || !errors.Is(err, ErrTooManyDraws) {
+		t.Errorf("Composite.NextOutcome() = %v, %v, want nil, %v", got, err, ErrTooManyDraws)
+	}
+}
+
+func TestComposite_TranslateOutcome(t *testing.T) {
+	c := NewComposite(NewCoinBag(1), NewDiceBag([]int{6}), NewTarotDeMarseilleDeck())
+	outcome := []rune{1, 3, TheFool}
+	want := []string{"[T]", "[4]", "0"}
+	got, err := TranslateOutcome(c, outcome)
+	if err != nil {
+		t.Fatalf("TranslateOutcome() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TranslateOutcome() = %v, want %v", got, want)
+	}
+	if _, err := TranslateOutcome(c, []rune{0, TheFool, 3}); err == nil {
+		t.Errorf("TranslateOutcome() succeeded with a card rolled on a die")
+	}
+}
+
+func TestCombined_MaxDraws(t *testing.T) {
+	c := NewCombined([]int{6, 6})
+	if got := c.MaxDraws(); got != 54 {
+		t.Errorf("Combined.MaxDraws() = %v, want 54", got)
+	}
+	n := 0
+	for o, _ := c.NextOutcome(3); o != nil; o, _ = c.NextOutcome(3) {
+		n++
+	}
+	if n != 36*52 {
+		t.Errorf("Combined.NextOutcome() enumerated %d outcomes, want %d", n, 36*52)
+	}
+}
@@ -0,0 +1,153 @@
+package cardware
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// CustomCardBase is the first rune assigned to cards of custom decks that do
+// not specify their own runes. Runes are assigned consecutively from the start
+// of Unicode's Supplementary Private Use Area-A.
+const CustomCardBase = '\U000F0000'
+
+// SuitDefinition describes a suit of a custom deck.
+type SuitDefinition struct {
+	// Name is the suit's display name, appended to rank names (for example, "♠").
+	Name string `json:"name"`
+	// ASCII is an optional ASCII name that may be typed in place of Name (for example, "S").
+	ASCII string `json:"ascii,omitempty"`
+	// Runes optionally assigns a Unicode rune to each rank of the suit, in rank order.
+	Runes string `json:"runes,omitempty"`
+	// Color is the optional name of the suit's color (for example, "R").
+	Color string `json:"color,omitempty"`
+}
+
+// TrumpDefinition describes a trump card of a custom deck.
+type TrumpDefinition struct {
+	// Name is the trump's display name.
+	Name string `json:"name"`
+	// Rune is an optional Unicode rune for the trump.
+	Rune string `json:"rune,omitempty"`
+}
+
+// DeckDefinition describes a custom deck of suited cards and trumps. The deck
+// holds one card for every rank of every suit, in suit then rank order,
+// followed by the trumps. Suited cards are named by their rank name followed
+// by their suit name. Card names must not contain whitespace, square brackets,
+// or plus signs, so that printed tables can be read back. Either every suit or
+// no suit should have a color; if no suit has a color, each suit is treated as
+// its own color.
+type DeckDefinition struct {
+	Name   string            `json:"name"`
+	Suits  []SuitDefinition  `json:"suits"`
+	Ranks  []string          `json:"ranks"`
+	Trumps []TrumpDefinition `json:"trumps,omitempty"`
+}
+
+// LoadDeck reads a JSON deck definition and builds the deck it describes.
+func LoadDeck(r io.Reader) (*Deck, error) {
+	var def DeckDefinition
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&def); err != nil {
+		return nil, err
+	}
+	return NewCustomDeck(def)
+}
+
+// NewCustomDeck builds a deck from a definition. Every card must have a
+// distinct name and a distinct rune.
+func NewCustomDeck(def DeckDefinition) (*Deck, error) {
+	nCards := len(def.Suits)*len(def.Ranks) + len(def.Trumps)
+	if nCards == 0 {
+		return nil, fmt.Errorf("deck \"%s\" : %w", def.Name, ErrEmptyDeck)
+	}
+	cards := make([]Card, 0, nCards)
+	names := make(map[rune]string, nCards)
+	seen := make(map[string]bool, nCards)
+	ascii := make([]asciiSuit, 0, len(def.Suits))
+	colors := make([]string, 0, len(def.Suits))
+	seenColors := make(map[string]bool, len(def.Suits))
+
+	next := CustomCardBase
+	add := func(name string, r rune) error {
+		if r == 0 {
+			r = next
+			next++
+		}
+		if _, ok := names[r]; ok {
+			return fmt.Errorf("deck \"%s\" : rune '%c' is repeated", def.Name, r)
+		}
+		if name == "" || strings.ContainsAny(name, "[]+") || strings.IndexFunc(name, unicode.IsSpace) >= 0 {
+			return fmt.Errorf("deck \"%s\" : card \"%s\" must be named without whitespace, square brackets, or plus signs", def.Name, name)
+		}
+		if seen[strings.ToUpper(name)] {
+			return fmt.Errorf("deck \"%s\" : card \"%s\" is repeated", def.Name, name)
+		}
+		cards = append(cards, Card(r))
+		names[r] = name
+		seen[strings.ToUpper(name)] = true
+		return nil
+	}
+
+	for _, suit := range def.Suits {
+		runes := []rune(suit.Runes)
+		if len(runes) != 0 && len(runes) != len(def.Ranks) {
+			return nil, fmt.Errorf("deck \"%s\" : suit \"%s\" has %d runes for %d ranks", def.Name, suit.Name, len(runes), len(def.Ranks))
+		}
+		if suit.ASCII != "" {
+			a := strings.ToUpper(suit.ASCII)
+			for _, b := range ascii {
+				if a == b.ascii {
+					return nil, fmt.Errorf("deck \"%s\" : ASCII suit \"%s\" is repeated", def.Name, suit.ASCII)
+				}
+			}
+			ascii = append(ascii, asciiSuit{a, suit.Name})
+		}
+		if (suit.Color == "") != (def.Suits[0].Color == "") {
+			return nil, fmt.Errorf("deck \"%s\" : either every suit or no suit must have a color", def.Name)
+		}
+		color := suit.Color
+		if color == "" {
+			color = suit.Name
+		}
+		if !seenColors[color] {
+			seenColors[color] = true
+			colors = append(colors, color)
+		}
+		for i, rank := range def.Ranks {
+			var r rune
+			if len(runes) != 0 {
+				r = runes[i]
+			}
+			if err := add(rank+suit.Name, r); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, trump := range def.Trumps {
+		var r rune
+		if runes := []rune(trump.Rune); len(runes) == 1 {
+			r = runes[0]
+		} else if len(runes) > 1 {
+			return nil, fmt.Errorf("deck \"%s\" : trump \"%s\" has more than one rune", def.Name, trump.Name)
+		}
+		if err := add(trump.Name, r); err != nil {
+			return nil, err
+		}
+	}
+
+	tr := func(r rune) (string, error) {
+		name, ok := names[r]
+		if !ok {
+			return "", fmt.Errorf("card '%c' is %w", r, ErrOutOfBounds)
+		}
+		return name, nil
+	}
+	values := make([]string, len(def.Ranks))
+	copy(values, def.Ranks)
+	return &Deck{cards: cards, tr: tr, ascii: ascii, values: values, colors: colors}, nil
+}
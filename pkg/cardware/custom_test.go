@@ -0,0 +1,127 @@
+package cardware
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadDeck(t *testing.T) {
+	tests := []struct {
+		name    string
+		def     string
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "suits-and-trumps",
+			def:  `{"name": "test", "suits": [{"name": "♠", "ascii": "S"}, {"name": "♡"}], "ranks": ["A", "K"], "trumps": [{"name": "Fool"}]}`,
+			want: 5,
+		},
+		{
+			name: "runes",
+			def:  `{"name": "test", "suits": [{"name": "♠", "runes": "🂡🂮"}], "ranks": ["A", "K"]}`,
+			want: 2,
+		},
+		{
+			name:    "empty",
+			def:     `{"name": "test"}`,
+			wantErr: true,
+		},
+		{
+			name:    "wrong-runes",
+			def:     `{"name": "test", "suits": [{"name": "♠", "runes": "🂡"}], "ranks": ["A", "K"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "repeated-name",
+			def:     `{"name": "test", "suits": [{"name": "♠"}], "ranks": ["A"], "trumps": [{"name": "a♠"}]}`,
+			wantErr: true,
+		},
+		{
+			name: "colors",
+			def:  `{"name": "test", "suits": [{"name": "♠", "color": "B"}, {"name": "♡", "color": "R"}, {"name": "♣", "color": "B"}], "ranks": ["A"]}`,
+			want: 3,
+		},
+		{
+			name:    "missing-color",
+			def:     `{"name": "test", "suits": [{"name": "♠", "color": "B"}, {"name": "♡"}], "ranks": ["A"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "whitespace",
+			def:     `{"name": "test", "suits": [{"name": " of Acorns"}], "ranks": ["A"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "bracket",
+			def:     `{"name": "test", "suits": [{"name": "♠"}], "ranks": ["A"], "trumps": [{"name": "[Fool]"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "plus",
+			def:     `{"name": "test", "suits": [{"name": "♠"}], "ranks": ["A+"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "repeated-ascii",
+			def:     `{"name": "test", "suits": [{"name": "♠", "ascii": "S"}, {"name": "♤", "ascii": "s"}], "ranks": ["A"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown-field",
+			def:     `{"name": "test", "suits": [{"name": "♠"}], "ranks": ["A"], "jokers": 2}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := LoadDeck(strings.NewReader(tt.def))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadDeck() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := d.MaxDraws(); got != tt.want {
+				t.Errorf("Deck.MaxDraws() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewCustomDeck_Translate(t *testing.T) {
+	def := DeckDefinition{
+		Name:   "test",
+		Suits:  []SuitDefinition{{Name: "♠", ASCII: "S"}, {Name: "-of-Acorns", ASCII: "A"}},
+		Ranks:  []string{"A", "Ober"},
+		Trumps: []TrumpDefinition{{Name: "Fool", Rune: "🃠"}},
+	}
+	d, err := NewCustomDeck(def)
+	if err != nil {
+		t.Fatalf("NewCustomDeck() error = %v", err)
+	}
+	want := []string{"A♠", "Ober♠", "A-of-Acorns", "Ober-of-Acorns", "Fool"}
+	for i, w := range want {
+		got, err := d.Translate(rune(d.Card(i)))
+		if err != nil || got != w {
+			t.Errorf("Deck.Translate() = %v, %v, want %v", got, err, w)
+		}
+	}
+	if d.Card(0) != CustomCardBase || d.Card(4) != '🃠' {
+		t.Errorf("NewCustomDeck() runes = %c, %c, want %c, %c", d.Card(0), d.Card(4), CustomCardBase, '🃠')
+	}
+	if r, err := d.Parse("oberA"); err != nil || r != rune(d.Card(3)) {
+		t.Errorf("Deck.Parse() = %c, %v, want %c", r, err, d.Card(3))
+	}
+}
+
+// customDeck loads a deck from a JSON definition, failing the test on error.
+func customDeck(t *testing.T, def string) *Deck {
+	t.Helper()
+	d, err := LoadDeck(strings.NewReader(def))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
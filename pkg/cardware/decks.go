@@ -3,8 +3,8 @@ package cardware
 import (
 	"fmt"
 	"math/big"
-
-	"gonum.org/v1/gonum/stat/combin"
+	"math/rand"
+	"strings"
 )
 
 // Card represents a single card in a deck of playing cards.
@@ -13,10 +13,15 @@ type Card rune
 // Deck represents a deck of playing cards.
 type Deck struct {
 	RandomObject
-	cards []Card
-	draws int
-	tr    func(rune) (string, error)
-	pg    *combin.PermutationGenerator
+	cards  []Card
+	tr     func(rune) (string, error)
+	parse  func(string) (Card, error)
+	ascii  []asciiSuit
+	values []string
+	colors []string
+	mode   DrawMode
+	draws  int
+	next   OutcomeIterator
 }
 
 // AceOfSpades is the lowest valued card in the deck.
@@ -25,6 +30,15 @@ const AceOfSpades = '🂡'
 // KingOfClubs is the highest valued non-trump card in the deck.
 const KingOfClubs = '🃞'
 
+// RedJoker is the red joker of a French deck.
+const RedJoker = '🂿'
+
+// BlackJoker is the black joker of a French deck.
+const BlackJoker = '🃏'
+
+// WhiteJoker is the white joker found in some French decks.
+const WhiteJoker = '🃟'
+
 // TheFool is the lowest valued trump card in the deck.
 const TheFool = '🃠'
 
@@ -37,15 +51,32 @@ var FrenchColors = []rune{'B', 'R'}
 // FrenchSuits are the four suits present in a standard French deck of cards.
 var FrenchSuits = []rune{'♠', '♡', '♢', '♣'}
 
+// FrenchSuitsASCII are the ASCII letters used in place of FrenchSuits when typing card names.
+var FrenchSuitsASCII = []rune{'S', 'H', 'D', 'C'}
+
 // FrenchValues are the thirteen values of cards for each suit in a standard French deck of cards.
 var FrenchValues = []rune{'A', '2', '3', '4', '5', '6', '7', '8', '9', 'T', 'J', 'Q', 'K'}
 
 // FrenchCards is a deck of 52 standard French cards.
 var FrenchCards = make([]rune, 52)
 
+// FrenchJokers are the jokers that may accompany a French deck: red, black, and white.
+var FrenchJokers = []rune{RedJoker, BlackJoker, WhiteJoker}
+
+// FrenchJokerNames are the names of FrenchJokers.
+var FrenchJokerNames = []string{"RJ", "BJ", "WJ"}
+
 // TarotDeMarseilleSuits are the four suits present in a Tarot de Marseille deck of cards.
 var TarotDeMarseilleSuits = []rune{'♣', '⚔', '⛾', '⛤'}
 
+// TarotDeMarseilleSuitsASCII are the ASCII letters used in place of TarotDeMarseilleSuits
+// when typing card names (wands, swords, cups, pentacles).
+var TarotDeMarseilleSuitsASCII = []rune{'W', 'S', 'C', 'P'}
+
+// TarotDeMarseilleColors group the suits of a Tarot de Marseille deck in place of
+// colors: the long suits (wands and swords) and the round suits (cups and pentacles).
+var TarotDeMarseilleColors = []rune{'L', 'R'}
+
 // TarotDeMarseilleValues are the fourteen values of cards for each suit in a Tarot de Marseille deck of cards.
 var TarotDeMarseilleValues = []rune{'A', '2', '3', '4', '5', '6', '7', '8', '9', 'T', 'J', 'N', 'Q', 'K'}
 
@@ -59,15 +90,14 @@ func init() {
 	fcard := 0
 	tcard := 0
 	for i := AceOfSpades; i <= KingOfClubs; i++ {
-		rank := (i - AceOfSpades) % 16
-		// skip empty runes and jokers
-		if rank >= 14 {
+		// skip jokers
+		if !Card(i).IsSuited() {
 			continue
 		}
 		TarotDeMarseilleCards[tcard] = i
 		tcard++
 		// skip knights
-		if rank == 11 {
+		if Card(i).Rank() == Knight {
 			continue
 		}
 		FrenchCards[fcard] = i
@@ -87,7 +117,28 @@ func NewStandardFrenchDeck() *Deck {
 	for i, c := range FrenchCards {
 		cards[i] = Card(c)
 	}
-	return &Deck{cards: cards, draws: -1, tr: TranslateFrench}
+	return &Deck{
+		cards:  cards,
+		tr:     TranslateFrench,
+		parse:  ParseCard,
+		values: runeStrings(FrenchValues),
+		colors: runeStrings(FrenchColors),
+	}
+}
+
+// NewFrenchDeckWithJokers builds a 54-card deck of the 52 standard French cards
+// plus a red and a black joker, or a 55-card deck if white is true and the white
+// joker is added as well.
+func NewFrenchDeckWithJokers(white bool) *Deck {
+	d := NewStandardFrenchDeck()
+	jokers := FrenchJokers[:2]
+	if white {
+		jokers = FrenchJokers
+	}
+	for _, j := range jokers {
+		d.cards = append(d.cards, Card(j))
+	}
+	return d
 }
 
 // NewTarotDeMarseilleDeck builds a 78-card deck with four Italian suits
@@ -98,99 +149,174 @@ func NewTarotDeMarseilleDeck() *Deck {
 	for i, c := range TarotDeMarseilleCards {
 		cards[i] = Card(c)
 	}
-	return &Deck{cards: cards, draws: -1, tr: TranslateTarotDeMarseille}
+	return &Deck{
+		cards:  cards,
+		tr:     TranslateTarotDeMarseille,
+		parse:  parseTarotDeMarseille,
+		values: runeStrings(TarotDeMarseilleValues),
+		colors: runeStrings(TarotDeMarseilleColors),
+	}
 }
 
-// MaxDraws implements RandomObject interface.
+// MaxDraws implements RandomObject interface. A deck drawn WithReplacement can
+// be drawn from any number of times, up to UnlimitedDraws.
 func (d *Deck) MaxDraws() int {
+	if d.mode == WithReplacement {
+		return UnlimitedDraws
+	}
 	return len(d.cards)
 }
 
 // CountDistinctOutcomes implements RandomObject interface.
-func (d *Deck) CountDistinctOutcomes(k int) *big.Int {
-	md := d.MaxDraws()
-	if k > md {
-		panic("k > MaxDraws")
-	}
-	if k < 0 {
-		panic("k < 0")
+func (d *Deck) CountDistinctOutcomes(k int) (*big.Int, error) {
+	if err := checkDraws(k, d.MaxDraws()); err != nil {
+		return nil, err
 	}
 	if k == 0 {
-		return big.NewInt(1)
+		return big.NewInt(1), nil
+	}
+	if d.mode == WithReplacement {
+		types, _ := d.multiset()
+		n := big.NewInt(int64(len(types)))
+		return n.Exp(n, big.NewInt(int64(k)), nil), nil
+	}
+	if d.HasDuplicates() {
+		_, counts := d.multiset()
+		if d.mode == Unordered {
+			return countMultisetCombinations(counts, k), nil
+		}
+		return countMultisetPermutations(counts, k), nil
 	}
 	n := big.NewInt(0)
-	return n.MulRange(int64(md-k+1), int64(md))
+	md := len(d.cards)
+	if d.mode == Unordered {
+		return n.Binomial(int64(md), int64(k)), nil
+	}
+	return n.MulRange(int64(md-k+1), int64(md)), nil
 }
 
-// NextOutcome implements RandomObject interface.
-func (d *Deck) NextOutcome(k int) []rune {
-	md := d.MaxDraws()
-	if k > md {
-		panic("k > MaxDraws")
-	}
-	if k < 0 {
-		panic("k < 0")
+// NextOutcome returns the next of the distinct outcomes of k draws, or nil once
+// every outcome has been returned or if k is zero. It returns an error if k
+// draws cannot be made.
+//
+// Deprecated: NextOutcome keeps its place in the enumeration in the deck, and
+// restarts whenever k changes. Use Outcomes instead.
+func (d *Deck) NextOutcome(k int) ([]rune, error) {
+	if err := checkDraws(k, d.MaxDraws()); err != nil {
+		return nil, err
 	}
 	if k == 0 {
-		return nil
+		return nil, nil
 	}
-	if k != d.draws || d.pg == nil {
+	if d.next == nil || k != d.draws {
+		next, err := d.Outcomes(k)
+		if err != nil {
+			return nil, err
+		}
 		d.draws = k
-		d.pg = combin.NewPermutationGenerator(md, k)
+		d.next = next
+	}
+	out := d.next.Next()
+	if out == nil {
+		d.next = nil
 	}
-	if !d.pg.Next() {
-		d.pg = nil
-		return nil
+	return out, nil
+}
+
+// RandomOutcome implements RandomObject interface.
+func (d *Deck) RandomOutcome(k int, src rand.Source) ([]rune, error) {
+	if err := checkDraws(k, d.MaxDraws()); err != nil {
+		return nil, err
 	}
-	p := make([]int, k)
-	d.pg.Permutation(p)
+	md := len(d.cards)
+	rng := rand.New(src)
+	if d.mode == WithReplacement {
+		out := make([]rune, k)
+		for i := range out {
+			out[i] = rune(d.cards[rng.Intn(md)])
+		}
+		return out, nil
+	}
+	idx := make([]int, md)
+	for i := range idx {
+		idx[i] = i
+	}
+	// partial Fisher-Yates shuffle: deal k cards from the top
 	out := make([]rune, k)
-	for i, x := range p {
-		out[i] = rune(d.cards[x])
+	for i := 0; i < k; i++ {
+		j := i + rng.Intn(md-i)
+		idx[i], idx[j] = idx[j], idx[i]
+		out[i] = rune(d.cards[idx[i]])
+	}
+	if d.mode == Unordered {
+		SortHand(out)
 	}
-	return out
+	return out, nil
+}
+
+// clone returns a copy of the deck with cards of its own, so the copy can be
+// shuffled or changed without disturbing d.
+func (d *Deck) clone() *Deck {
+	c := *d
+	c.cards = make([]Card, len(d.cards))
+	copy(c.cards, d.cards)
+	c.next = nil
+	return &c
+}
+
+// snapshot implements snapshotter interface.
+func (d *Deck) snapshot() RandomObject {
+	return d.clone()
 }
 
-// TranslateFrench translates a playing card rune into a text name.
+// Shuffle implements Shuffler interface. It reorders the cards of the deck and
+// restarts enumeration, so outcomes are enumerated in the order of the shuffled
+// deck rather than in card order. Iterators already returned by Outcomes are
+// not affected.
+func (d *Deck) Shuffle(src rand.Source) {
+	rand.New(src).Shuffle(len(d.cards), func(i, j int) {
+		d.cards[i], d.cards[j] = d.cards[j], d.cards[i]
+	})
+	d.next = nil
+}
+
+// TranslateFrench translates a playing card rune into a text name. Jokers are
+// named by FrenchJokerNames.
 func TranslateFrench(r rune) (string, error) {
-	if r < AceOfSpades || r > KingOfClubs {
-		return "", fmt.Errorf("card '%c' is out of bounds", r)
+	for i, j := range FrenchJokers {
+		if r == j {
+			return FrenchJokerNames[i], nil
+		}
 	}
-	suit := int(r-AceOfSpades) / 16
-	if suit < 0 || suit >= len(FrenchSuits) {
-		return "", fmt.Errorf("suit of card '%c' is unknown", r)
+	c := Card(r)
+	if !c.IsSuited() {
+		return "", fmt.Errorf("card '%c' is %w", r, ErrOutOfBounds)
 	}
-	rank := int(r-AceOfSpades) % 16
-	if rank < 0 || rank == 11 || rank > 13 {
-		return "", fmt.Errorf("rank of card '%c' is unknown", r)
+	if c.Rank() == Knight {
+		return "", fmt.Errorf("rank of card '%c' is unknown: %w", r, ErrOutOfBounds)
 	}
+	rank := int(c.Rank() - Ace)
 	// skip knight
-	if rank > 11 {
-		rank -= 1
+	if c.Rank() > Knight {
+		rank--
 	}
-	return string(FrenchValues[rank]) + string(FrenchSuits[suit]), nil
+	return string(FrenchValues[rank]) + string(FrenchSuits[c.Suit()]), nil
 }
 
 // TranslateTarotDeMarseille translates a playing card rune into a text name.
 func TranslateTarotDeMarseille(r rune) (string, error) {
 	if r < AceOfSpades || r > TheWorld {
-		return "", fmt.Errorf("card '%c' is out of bounds", r)
+		return "", fmt.Errorf("card '%c' is %w", r, ErrOutOfBounds)
 	}
+	c := Card(r)
 	// translate trumps first
-	if r >= TheFool {
-		rank := int(r - TheFool)
-		return TarotDeMarseilleTrumps[rank], nil
-	}
-
-	suit := int(r-AceOfSpades) / 16
-	if suit < 0 || suit >= len(TarotDeMarseilleSuits) {
-		return "", fmt.Errorf("suit of card '%c' is unknown", r)
+	if c.IsTrump() {
+		return TarotDeMarseilleTrumps[c.Trump()], nil
 	}
-	rank := int(r-AceOfSpades) % 16
-	if rank < 0 || rank > 14 {
-		return "", fmt.Errorf("rank of card '%c' is unknown", r)
+	if !c.IsSuited() {
+		return "", fmt.Errorf("rank of card '%c' is unknown: %w", r, ErrOutOfBounds)
 	}
-	return string(TarotDeMarseilleValues[rank]) + string(TarotDeMarseilleSuits[suit]), nil
+	return string(TarotDeMarseilleValues[c.Rank()-Ace]) + string(TarotDeMarseilleSuits[c.Suit()]), nil
 }
 
 // Translate implements RandomObject interface.
@@ -198,6 +324,105 @@ func (d *Deck) Translate(r rune) (string, error) {
 	return d.tr(r)
 }
 
+// Parse translates a card name back into the card's rune, reversing Translate.
+// Names are case-insensitive, suits may be typed with their ASCII letters (for
+// example, "AS" for the ace of spades), and "10" may be typed for "T" in decks
+// with no card named with "10". French and tarot cards are parsed as by
+// ParseCard. In custom decks, if a name typed with ASCII letters could name
+// more than one card, the suit that comes first in the deck is chosen.
+func (d *Deck) Parse(name string) (rune, error) {
+	name = strings.TrimSpace(name)
+	if d.parse != nil {
+		c, err := d.parse(name)
+		if err != nil {
+			return 0, err
+		}
+		for _, x := range d.cards {
+			if x == c {
+				return rune(c), nil
+			}
+		}
+		return 0, fmt.Errorf("card '%s' is not in the deck: %w", name, ErrOutOfBounds)
+	}
+	if r, ok := d.find(name); ok {
+		return r, nil
+	}
+	if strings.HasPrefix(name, "10") {
+		if r, ok := d.find("T" + name[2:]); ok {
+			return r, nil
+		}
+	}
+	return 0, fmt.Errorf("card '%s' is not in the deck: %w", name, ErrOutOfBounds)
+}
+
+// parseOutcome implements parser interface. Hands drawn in Unordered mode are
+// sorted, so their cards may be named in any order.
+func (d *Deck) parseOutcome(names []string) ([]rune, error) {
+	out := make([]rune, len(names))
+	for i, name := range names {
+		r, err := d.Parse(name)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = r
+	}
+	if d.mode == Unordered {
+		SortHand(out)
+	}
+	return out, nil
+}
+
+// find returns the card with the given name or, failing that, the name with
+// an ASCII suit replaced by the suit it stands for.
+func (d *Deck) find(name string) (rune, bool) {
+	candidates := []string{name}
+	upper := strings.ToUpper(name)
+	for _, a := range d.ascii {
+		if len(upper) > len(a.ascii) && strings.HasSuffix(upper, a.ascii) {
+			candidates = append(candidates, name[:len(name)-len(a.ascii)]+a.suit)
+		}
+	}
+	for _, candidate := range candidates {
+		for _, c := range d.cards {
+			n, err := d.tr(rune(c))
+			if err == nil && strings.EqualFold(n, candidate) {
+				return rune(c), true
+			}
+		}
+	}
+	return 0, false
+}
+
+// asciiSuit is an ASCII name that may be typed in place of the name of a suit.
+type asciiSuit struct {
+	ascii string
+	suit  string
+}
+
+// Values returns the names of the values of the deck's suited cards, in rank
+// order. Trumps and jokers have no value.
+func (d *Deck) Values() []string {
+	v := make([]string, len(d.values))
+	copy(v, d.values)
+	return v
+}
+
+// Colors returns the names of the colors of the deck's suited cards. Together
+// with Values, they index the symbol table.
+func (d *Deck) Colors() []string {
+	c := make([]string, len(d.colors))
+	copy(c, d.colors)
+	return c
+}
+
+func runeStrings(runes []rune) []string {
+	s := make([]string, len(runes))
+	for i, r := range runes {
+		s[i] = string(r)
+	}
+	return s
+}
+
 // Card gets the nth card from the deck with no bounds checking
 func (d *Deck) Card(n int) Card {
 	return d.cards[n]
@@ -1,7 +1,9 @@
 package cardware
 
 import (
+	"errors"
 	"math/big"
+	"math/rand"
 	"reflect"
 	"testing"
 )
@@ -32,6 +34,16 @@ func TestDeck_MaxDraws(t *testing.T) {
 			NewTarotDeMarseilleDeck(),
 			78,
 		},
+		{
+			"jokers",
+			NewFrenchDeckWithJokers(false),
+			54,
+		},
+		{
+			"white-joker",
+			NewFrenchDeckWithJokers(true),
+			55,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -54,92 +66,82 @@ func TestDeck_CountDistinctOutcomes(t *testing.T) {
 		k int
 	}
 	tests := []struct {
-		name      string
-		d         *Deck
-		args      args
-		want      *big.Int
-		wantPanic bool
+		name    string
+		d       *Deck
+		args    args
+		want    *big.Int
+		wantErr error
 	}{
 		{
-			name:      "zero",
-			d:         &Deck{},
-			args:      args{k: 0},
-			want:      big.NewInt(int64(1)),
-			wantPanic: false,
+			name: "zero",
+			d:    &Deck{},
+			args: args{k: 0},
+			want: big.NewInt(int64(1)),
 		},
 		{
-			name:      "one",
-			d:         &Deck{cards: []Card{'A'}},
-			args:      args{k: 1},
-			want:      big.NewInt(int64(1)),
-			wantPanic: false,
+			name: "one",
+			d:    &Deck{cards: []Card{'A'}},
+			args: args{k: 1},
+			want: big.NewInt(int64(1)),
 		},
 		{
-			name:      "standard-0",
-			d:         NewStandardFrenchDeck(),
-			args:      args{k: 0},
-			want:      big.NewInt(int64(1)),
-			wantPanic: false,
+			name: "standard-0",
+			d:    NewStandardFrenchDeck(),
+			args: args{k: 0},
+			want: big.NewInt(int64(1)),
 		},
 		{
-			name:      "standard-1",
-			d:         NewStandardFrenchDeck(),
-			args:      args{k: 1},
-			want:      big.NewInt(int64(52)),
-			wantPanic: false,
+			name: "standard-1",
+			d:    NewStandardFrenchDeck(),
+			args: args{k: 1},
+			want: big.NewInt(int64(52)),
 		},
 		{
-			name:      "standard-52",
-			d:         NewStandardFrenchDeck(),
-			args:      args{k: 52},
-			want:      fac52,
-			wantPanic: false,
+			name: "standard-52",
+			d:    NewStandardFrenchDeck(),
+			args: args{k: 52},
+			want: fac52,
 		},
 		{
-			name:      "tarot-de-marseille-0",
-			d:         NewTarotDeMarseilleDeck(),
-			args:      args{k: 0},
-			want:      big.NewInt(int64(1)),
-			wantPanic: false,
+			name: "tarot-de-marseille-0",
+			d:    NewTarotDeMarseilleDeck(),
+			args: args{k: 0},
+			want: big.NewInt(int64(1)),
 		},
 		{
-			name:      "tarot-de-marseille-1",
-			d:         NewTarotDeMarseilleDeck(),
-			args:      args{k: 1},
-			want:      big.NewInt(int64(78)),
-			wantPanic: false,
+			name: "tarot-de-marseille-1",
+			d:    NewTarotDeMarseilleDeck(),
+			args: args{k: 1},
+			want: big.NewInt(int64(78)),
 		},
 		{
-			name:      "tarot-de-marseille-78",
-			d:         NewTarotDeMarseilleDeck(),
-			args:      args{k: 78},
-			want:      fac78,
-			wantPanic: false,
+			name: "tarot-de-marseille-78",
+			d:    NewTarotDeMarseilleDeck(),
+			args: args{k: 78},
+			want: fac78,
 		},
 		{
-			name:      "too-many",
-			d:         &Deck{},
-			args:      args{k: 1},
-			want:      nil,
-			wantPanic: true,
+			name:    "too-many",
+			d:       &Deck{},
+			args:    args{k: 1},
+			want:    nil,
+			wantErr: ErrTooManyDraws,
 		},
 		{
-			name:      "negative",
-			d:         &Deck{},
-			args:      args{k: -1},
-			want:      nil,
-			wantPanic: true,
+			name:    "negative",
+			d:       &Deck{},
+			args:    args{k: -1},
+			want:    nil,
+			wantErr: ErrNegativeDraws,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			defer func() {
-				r := recover()
-				if (r != nil) != tt.wantPanic {
-					t.Errorf("Deck.CountDistinctOutcomes() recover = %v, wantPanic = %v", r, tt.wantPanic)
-				}
-			}()
-			if got := tt.d.CountDistinctOutcomes(tt.args.k); !reflect.DeepEqual(got, tt.want) {
+			got, err := tt.d.CountDistinctOutcomes(tt.args.k)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Deck.CountDistinctOutcomes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Deck.CountDistinctOutcomes() = %v, want %v", got, tt.want)
 			}
 		})
@@ -151,64 +153,56 @@ func TestDeck_NextOutcome(t *testing.T) {
 		k int
 	}
 	tests := []struct {
-		name      string
-		d         *Deck
-		args      args
-		want      []rune
-		wantPanic bool
+		name    string
+		d       *Deck
+		args    args
+		want    []rune
+		wantErr error
 	}{
 		{
-			name:      "zero",
-			d:         &Deck{},
-			args:      args{k: 0},
-			want:      []rune{},
-			wantPanic: false,
+			name: "zero",
+			d:    &Deck{},
+			args: args{k: 0},
+			want: nil,
 		},
 		{
-			name:      "one",
-			d:         &Deck{cards: []Card{'A'}},
-			args:      args{k: 1},
-			want:      []rune{'A'},
-			wantPanic: false,
+			name: "one",
+			d:    &Deck{cards: []Card{'A'}},
+			args: args{k: 1},
+			want: []rune{'A'},
 		},
 		{
-			name:      "two",
-			d:         &Deck{cards: []Card{'A', 'B'}},
-			args:      args{k: 2},
-			want:      []rune{'A', 'B'},
-			wantPanic: false,
+			name: "two",
+			d:    &Deck{cards: []Card{'A', 'B'}},
+			args: args{k: 2},
+			want: []rune{'A', 'B'},
 		},
 		{
-			name:      "two-1",
-			d:         &Deck{cards: []Card{'A', 'B'}},
-			args:      args{k: 1},
-			want:      []rune{'A'},
-			wantPanic: false,
+			name: "two-1",
+			d:    &Deck{cards: []Card{'A', 'B'}},
+			args: args{k: 1},
+			want: []rune{'A'},
 		},
 		{
-			name:      "negative",
-			d:         &Deck{cards: []Card{'A', 'B'}},
-			args:      args{k: -1},
-			want:      nil,
-			wantPanic: true,
+			name:    "negative",
+			d:       &Deck{cards: []Card{'A', 'B'}},
+			args:    args{k: -1},
+			wantErr: ErrNegativeDraws,
 		},
 		{
-			name:      "too-many",
-			d:         &Deck{cards: []Card{'A', 'B'}},
-			args:      args{k: 3},
-			want:      nil,
-			wantPanic: true,
+			name:    "too-many",
+			d:       &Deck{cards: []Card{'A', 'B'}},
+			args:    args{k: 3},
+			wantErr: ErrTooManyDraws,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			defer func() {
-				r := recover()
-				if (r != nil) != tt.wantPanic {
-					t.Errorf("Deck.NextOutcome() recover = %v, wantPanic = %v", r, tt.wantPanic)
-				}
-			}()
-			if got := tt.d.NextOutcome(tt.args.k); !reflect.DeepEqual(got, tt.want) {
+			got, err := tt.d.NextOutcome(tt.args.k)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Deck.NextOutcome() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Deck.NextOutcome() = %v, want %v", got, tt.want)
 			}
 		})
@@ -218,10 +212,10 @@ func TestDeck_NextOutcome(t *testing.T) {
 		want1 := []rune{'🂡', '🂢', '🂣', '🂤', '🂥'}
 		want2 := []rune{'🂡', '🂢', '🂣', '🂥', '🂤'}
 		deck := NewStandardFrenchDeck()
-		if got := deck.NextOutcome(5); !reflect.DeepEqual(got, want1) {
+		if got, _ := deck.NextOutcome(5); !reflect.DeepEqual(got, want1) {
 			t.Errorf("Deck.NextOutcome() = %v, want %v", got, want1)
 		}
-		if got := deck.NextOutcome(5); !reflect.DeepEqual(got, want2) {
+		if got, _ := deck.NextOutcome(5); !reflect.DeepEqual(got, want2) {
 			t.Errorf("Deck.NextOutcome() = %v, want %v", got, want2)
 		}
 	})
@@ -229,9 +223,9 @@ func TestDeck_NextOutcome(t *testing.T) {
 	t.Run("count-3-3-twice", func(t *testing.T) {
 		one := big.NewInt(int64(1))
 		deck := &Deck{cards: []Card{'A', 'B', 'C'}}
-		want := deck.CountDistinctOutcomes(3)
+		want, _ := deck.CountDistinctOutcomes(3)
 		i := big.NewInt(int64(0))
-		for deck.NextOutcome(3) != nil {
+		for o, _ := deck.NextOutcome(3); o != nil; o, _ = deck.NextOutcome(3) {
 			i.Add(i, one)
 			// keep going!
 		}
@@ -239,7 +233,7 @@ func TestDeck_NextOutcome(t *testing.T) {
 			t.Errorf("count = %v, want %v", i, want)
 		}
 		i = big.NewInt(int64(0))
-		for deck.NextOutcome(3) != nil {
+		for o, _ := deck.NextOutcome(3); o != nil; o, _ = deck.NextOutcome(3) {
 			i.Add(i, one)
 			// keep going!
 		}
@@ -278,8 +272,26 @@ func TestTranslateFrench(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name:    "too-many",
+			name:    "red-joker",
+			args:    args{r: '🂿'},
+			want:    "RJ",
+			wantErr: false,
+		},
+		{
+			name:    "white-joker",
 			args:    args{r: '🃟'},
+			want:    "WJ",
+			wantErr: false,
+		},
+		{
+			name:    "empty-joker",
+			args:    args{r: '🂯'},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name:    "too-many",
+			args:    args{r: '🃠'},
 			want:    "",
 			wantErr: true,
 		},
@@ -358,3 +370,258 @@ func TestTranslateTarotDeMarseille(t *testing.T) {
 		})
 	}
 }
+
+func TestDeck_Parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		d       *Deck
+		card    string
+		want    rune
+		wantErr bool
+	}{
+		{
+			name: "french-unicode",
+			d:    NewStandardFrenchDeck(),
+			card: "A♠",
+			want: '🂡',
+		},
+		{
+			name: "french-ascii",
+			d:    NewStandardFrenchDeck(),
+			card: "kc",
+			want: '🃞',
+		},
+		{
+			name: "french-ten",
+			d:    NewStandardFrenchDeck(),
+			card: "10H",
+			want: '🂺',
+		},
+		{
+			name:    "french-knight",
+			d:       NewStandardFrenchDeck(),
+			card:    "NS",
+			wantErr: true,
+		},
+		{
+			name: "tarot-ascii",
+			d:    NewTarotDeMarseilleDeck(),
+			card: "KP",
+			want: '🃞',
+		},
+		{
+			name: "tarot-trump",
+			d:    NewTarotDeMarseilleDeck(),
+			card: "xxi",
+			want: '🃵',
+		},
+		{
+			name: "tarot-knight",
+			d:    NewTarotDeMarseilleDeck(),
+			card: "nc",
+			want: '🃌',
+		},
+		{
+			name: "joker",
+			d:    NewFrenchDeckWithJokers(false),
+			card: "RJ",
+			want: RedJoker,
+		},
+		{
+			name:    "joker-not-in-deck",
+			d:       NewStandardFrenchDeck(),
+			card:    "RJ",
+			wantErr: true,
+		},
+		{
+			name:    "trump-not-in-deck",
+			d:       NewStandardFrenchDeck(),
+			card:    "XXI",
+			wantErr: true,
+		},
+		{
+			name: "pinochle",
+			d:    NewPinochleDeck(),
+			card: "9♢",
+			want: '🃉',
+		},
+		{
+			name:    "stripped",
+			d:       NewEuchreDeck(),
+			card:    "8S",
+			wantErr: true,
+		},
+		{
+			name: "custom-ten",
+			d:    customDeck(t, `{"name": "test", "suits": [{"name": "♠", "ascii": "S"}], "ranks": ["9", "10", "T"]}`),
+			card: "10S",
+			want: CustomCardBase + 1,
+		},
+		{
+			name: "custom-ten-fallback",
+			d:    customDeck(t, `{"name": "test", "suits": [{"name": "♠", "ascii": "S"}], "ranks": ["9", "T"]}`),
+			card: "10S",
+			want: CustomCardBase + 1,
+		},
+		{
+			name: "custom-colliding-ascii",
+			d:    customDeck(t, `{"name": "test", "suits": [{"name": "X", "ascii": "B"}, {"name": "Y", "ascii": "AB"}], "ranks": ["A", "AA"]}`),
+			card: "AAB",
+			want: CustomCardBase + 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.d.Parse(tt.card)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Deck.Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && !errors.Is(err, ErrOutOfBounds) {
+				t.Errorf("Deck.Parse() error = %v, want %v", err, ErrOutOfBounds)
+			}
+			if got != tt.want {
+				t.Errorf("Deck.Parse() = %c, want %c", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeck_Shuffle(t *testing.T) {
+	deck := NewStandardFrenchDeck()
+	if _, err := deck.NextOutcome(2); err != nil {
+		t.Fatalf("Deck.NextOutcome() error = %v", err)
+	}
+	deck.Shuffle(rand.NewSource(42))
+
+	seen := make(map[Card]bool)
+	for i := 0; i < deck.MaxDraws(); i++ {
+		seen[deck.Card(i)] = true
+	}
+	for _, c := range FrenchCards {
+		if !seen[Card(c)] {
+			t.Errorf("Deck.Shuffle() lost card %c", c)
+		}
+	}
+
+	want := []rune{rune(deck.Card(0)), rune(deck.Card(1))}
+	if got, _ := deck.NextOutcome(2); !reflect.DeepEqual(got, want) {
+		t.Errorf("Deck.NextOutcome() after Shuffle() = %v, want %v", got, want)
+	}
+}
+
+func TestDeck_Multiset(t *testing.T) {
+	tests := []struct {
+		name string
+		d    *Deck
+		k    int
+		want int64
+	}{
+		{
+			name: "aab-2",
+			d:    &Deck{cards: []Card{'A', 'A', 'B'}},
+			k:    2,
+			want: 3, // AA, AB, BA
+		},
+		{
+			name: "aab-3",
+			d:    &Deck{cards: []Card{'A', 'A', 'B'}},
+			k:    3,
+			want: 3, // AAB, ABA, BAA
+		},
+		{
+			name: "aabb-2",
+			d:    &Deck{cards: []Card{'A', 'B', 'A', 'B'}},
+			k:    2,
+			want: 4,
+		},
+		{
+			name: "double-french-2",
+			d:    NewRepeatedDeck(NewStandardFrenchDeck(), 2),
+			k:    2,
+			want: 52 * 52,
+		},
+		{
+			name: "pinochle-3",
+			d:    NewPinochleDeck(),
+			k:    3,
+			want: 24*24*24 - 24, // no card appears three times
+		},
+		{
+			name: "pinochle-0",
+			d:    NewPinochleDeck(),
+			k:    0,
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := tt.d.CountDistinctOutcomes(tt.k); err != nil || got.Cmp(big.NewInt(tt.want)) != 0 {
+				t.Errorf("Deck.CountDistinctOutcomes() = %v, want %v", got, tt.want)
+			}
+			seen := make(map[string]bool)
+			for _, o := range collect(tt.d.Outcomes(tt.k)) {
+				s := string(o)
+				if seen[s] {
+					t.Errorf("Deck.Outcomes() repeated %q", s)
+				}
+				seen[s] = true
+			}
+			if int64(len(seen)) != tt.want {
+				t.Errorf("Deck.Outcomes() generated %d outcomes, want %d", len(seen), tt.want)
+			}
+		})
+	}
+}
+
+func TestDeck_ValuesColors(t *testing.T) {
+	custom, err := NewCustomDeck(DeckDefinition{
+		Name:  "test",
+		Suits: []SuitDefinition{{Name: "♠"}, {Name: "♡"}, {Name: "♣"}},
+		Ranks: []string{"A", "K"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		d      *Deck
+		values []string
+		colors []string
+	}{
+		{
+			"standard",
+			NewStandardFrenchDeck(),
+			[]string{"A", "2", "3", "4", "5", "6", "7", "8", "9", "T", "J", "Q", "K"},
+			[]string{"B", "R"},
+		},
+		{
+			"tarot-de-marseille",
+			NewTarotDeMarseilleDeck(),
+			[]string{"A", "2", "3", "4", "5", "6", "7", "8", "9", "T", "J", "N", "Q", "K"},
+			[]string{"L", "R"},
+		},
+		{
+			"pinochle",
+			NewPinochleDeck(),
+			[]string{"A", "9", "T", "J", "Q", "K"},
+			[]string{"B", "R"},
+		},
+		{
+			"custom",
+			custom,
+			[]string{"A", "K"},
+			[]string{"♠", "♡", "♣"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.Values(); !reflect.DeepEqual(got, tt.values) {
+				t.Errorf("Deck.Values() = %v, want %v", got, tt.values)
+			}
+			if got := tt.d.Colors(); !reflect.DeepEqual(got, tt.colors) {
+				t.Errorf("Deck.Colors() = %v, want %v", got, tt.colors)
+			}
+		})
+	}
+}
@@ -3,23 +3,73 @@ package cardware
 import (
 	"fmt"
 	"math/big"
-
-	"gonum.org/v1/gonum/stat/combin"
+	"math/rand"
+	"strconv"
 )
 
 // DiceBag represents a bag of individual dice.
+//
+// Outcomes identify faces across the whole bag: the rune for face f of the ith
+// die is f plus the total number of faces of the dice before it.
 type DiceBag struct {
 	RandomObject
-	dice  []int
-	using int
-	cg    *combin.CartesianGenerator
+	dice   []int
+	labels [][]string
+	order  [][]int
+	mode   DrawMode
+	using  int
+	next   OutcomeIterator
 }
 
-// NewDiceBag creates a new bag of dice from a collection of dice.
+// FudgeFaces are the labels of a Fudge die. Each label appears on two of the
+// die's six faces, so the die is treated as a three-sided die.
+var FudgeFaces = []string{"-", "0", "+"}
+
+// NewDiceBag creates a new bag of dice from a collection of dice, given by
+// their numbers of faces. Every die must have at least one face.
 func NewDiceBag(dice []int) *DiceBag {
 	d := make([]int, len(dice))
 	copy(d, dice)
-	return &DiceBag{dice: d}
+	return &DiceBag{dice: d, labels: make([][]string, len(dice))}
+}
+
+// NewLabeledDiceBag creates a new bag of dice whose faces carry the given
+// labels, one slice of labels per die. Every die must have at least one face.
+// Labels on a die should be distinct and must not contain whitespace or square
+// brackets, so that printed tables can be read back.
+func NewLabeledDiceBag(labels [][]string) *DiceBag {
+	d := make([]int, len(labels))
+	l := make([][]string, len(labels))
+	for i, faces := range labels {
+		d[i] = len(faces)
+		l[i] = make([]string, len(faces))
+		copy(l[i], faces)
+	}
+	return &DiceBag{dice: d, labels: l}
+}
+
+// clone returns a copy of the dice bag that can be shuffled or changed without
+// disturbing d.
+func (d *DiceBag) clone() *DiceBag {
+	c := &DiceBag{dice: make([]int, len(d.dice)), labels: make([][]string, len(d.labels)), order: make([][]int, len(d.order)), mode: d.mode}
+	copy(c.dice, d.dice)
+	copy(c.labels, d.labels)
+	copy(c.order, d.order)
+	return c
+}
+
+// snapshot implements snapshotter interface.
+func (d *DiceBag) snapshot() RandomObject {
+	return d.clone()
+}
+
+// offset returns the rune of the first face of the ith die.
+func (d *DiceBag) offset(i int) int {
+	o := 0
+	for _, f := range d.dice[:i] {
+		o += f
+	}
+	return o
 }
 
 // MaxDraws implements RandomObject interface.
@@ -28,54 +78,159 @@ func (d *DiceBag) MaxDraws() int {
 }
 
 // CountDistinctOutcomes implements RandomObject interface.
-func (d *DiceBag) CountDistinctOutcomes(k int) *big.Int {
-	md := d.MaxDraws()
-	if k > md {
-		panic("k > MaxDraws")
-	}
-	if k < 0 {
-		panic("k < 0")
+func (d *DiceBag) CountDistinctOutcomes(k int) (*big.Int, error) {
+	if err := checkDraws(k, d.MaxDraws()); err != nil {
+		return nil, err
 	}
 	n := big.NewInt(1)
 	if k == 0 {
-		return n
+		return n, nil
+	}
+	if d.mode == Unordered {
+		_, count := d.rollClass(k)
+		return count, nil
 	}
 	for i := 0; i < k; i++ {
 		n.Mul(n, big.NewInt(int64(d.dice[i])))
 	}
-	return n
+	return n, nil
 }
 
-// NextOutcome implements RandomObject interface.
-func (d *DiceBag) NextOutcome(k int) []rune {
-	md := d.MaxDraws()
-	if k > md {
-		panic("k > MaxDraws")
-	}
-	if k < 0 {
-		panic("k < 0")
+// NextOutcome returns the next of the distinct outcomes of k rolls, or nil once
+// every outcome has been returned or if k is zero. It returns an error if k
+// dice cannot be rolled.
+//
+// Deprecated: NextOutcome keeps its place in the enumeration in the dice bag,
+// and restarts whenever k changes. Use Outcomes instead.
+func (d *DiceBag) NextOutcome(k int) ([]rune, error) {
+	if err := checkDraws(k, d.MaxDraws()); err != nil {
+		return nil, err
 	}
 	if k == 0 {
-		return nil
+		return nil, nil
 	}
-	if k != d.using || d.cg == nil {
+	if d.next == nil || k != d.using {
+		next, err := d.Outcomes(k)
+		if err != nil {
+			return nil, err
+		}
 		d.using = k
-		d.cg = combin.NewCartesianGenerator(d.dice[:k])
+		d.next = next
+	}
+	out := d.next.Next()
+	if out == nil {
+		d.next = nil
+	}
+	return out, nil
+}
+
+// RandomOutcome implements RandomObject interface.
+func (d *DiceBag) RandomOutcome(k int, src rand.Source) ([]rune, error) {
+	if err := checkDraws(k, d.MaxDraws()); err != nil {
+		return nil, err
 	}
-	if !d.cg.Next() {
-		d.cg = nil
-		return nil
+	if d.mode == Unordered {
+		return d.randomRoll(k, src), nil
 	}
-	p := make([]int, k)
-	d.cg.Product(p)
+	rng := rand.New(src)
 	out := make([]rune, k)
-	for i, x := range p {
-		out[i] = rune(x)
+	for i := range out {
+		out[i] = rune(d.offset(i) + rng.Intn(d.dice[i]))
 	}
-	return out
+	return out, nil
 }
 
-// Translate implements RandomObject interface.
+// Shuffle implements Shuffler interface. It reorders the faces of each die and
+// restarts enumeration, so outcomes are enumerated in the order of the shuffled
+// faces rather than in face order. Identical dice are shuffled alike. The dice
+// keep their places in the bag, so the outcomes and their counts do not change.
+// Iterators already returned by Outcomes are not affected.
+func (d *DiceBag) Shuffle(src rand.Source) {
+	rng := rand.New(src)
+	order := make([][]int, len(d.dice))
+	for _, group := range d.groups(len(d.dice)) {
+		perm := rng.Perm(d.dice[group[0]])
+		for _, i := range group {
+			order[i] = perm
+		}
+	}
+	d.order = order
+	d.next = nil
+}
+
+// face returns the face of the ith die enumerated in position x.
+func (d *DiceBag) face(i, x int) int {
+	if i < len(d.order) && d.order[i] != nil {
+		return d.order[i][x]
+	}
+	return x
+}
+
+// position returns the position in which face f of the ith die is enumerated.
+func (d *DiceBag) position(i, f int) int {
+	if i < len(d.order) && d.order[i] != nil {
+		for x, g := range d.order[i] {
+			if g == f {
+				return x
+			}
+		}
+	}
+	return f
+}
+
+// Translate implements RandomObject interface. Faces are translated to their
+// labels or, for dice without labels, to their numbers counting from one, and
+// are enclosed in square brackets to tell them apart from cards when printed.
 func (d *DiceBag) Translate(r rune) (string, error) {
-	return fmt.Sprintf("[%d]", int(r)+1), nil
+	label, err := d.label(r)
+	if err != nil {
+		return "", err
+	}
+	return "[" + label + "]", nil
+}
+
+// label returns the label of a face, or its number counting from one for dice
+// without labels, without the brackets that Translate adds.
+func (d *DiceBag) label(r rune) (string, error) {
+	face := int(r)
+	if face < 0 {
+		return "", fmt.Errorf("face '%d' is %w", face, ErrOutOfBounds)
+	}
+	for i, f := range d.dice {
+		if face < f {
+			if i < len(d.labels) && d.labels[i] != nil {
+				return d.labels[i][face], nil
+			}
+			return strconv.Itoa(face + 1), nil
+		}
+		face -= f
+	}
+	return "", fmt.Errorf("face '%d' is %w", int(r), ErrOutOfBounds)
+}
+
+// parseOutcome implements parser interface. Each name is the label of a face of
+// the die rolled in its position, or its number for dice without labels.
+// Identical dice rolled in Unordered mode may be named in any order.
+func (d *DiceBag) parseOutcome(names []string) ([]rune, error) {
+	if err := checkDraws(len(names), d.MaxDraws()); err != nil {
+		return nil, err
+	}
+	out := make([]rune, len(names))
+	for i, name := range names {
+		face := -1
+		for f := 0; f < d.dice[i]; f++ {
+			if label, _ := d.label(rune(d.offset(i) + f)); label == name {
+				face = f
+				break
+			}
+		}
+		if face < 0 {
+			return nil, fmt.Errorf("face '%s' of die %d is %w", name, i+1, ErrOutOfBounds)
+		}
+		out[i] = rune(d.offset(i) + face)
+	}
+	if d.mode == Unordered {
+		d.sortRoll(out)
+	}
+	return out, nil
 }
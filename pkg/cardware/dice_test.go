@@ -0,0 +1,157 @@
+package cardware
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestDiceBag_Translate(t *testing.T) {
+	bag := NewLabeledDiceBag([][]string{FudgeFaces, {"1", "2", "3", "4", "5", "6"}, {"red", "green"}})
+	want := []string{"[-]", "[0]", "[+]", "[1]", "[2]", "[3]", "[4]", "[5]", "[6]", "[red]", "[green]"}
+	for r, w := range want {
+		if got, err := bag.Translate(rune(r)); err != nil || got != w {
+			t.Errorf("DiceBag.Translate(%d) = %v, %v, want %v", r, got, err, w)
+		}
+	}
+	if _, err := bag.Translate(rune(len(want))); err == nil {
+		t.Errorf("DiceBag.Translate(%d) succeeded, want error", len(want))
+	}
+
+	outcomes := 0
+	for o, _ := bag.NextOutcome(3); o != nil; o, _ = bag.NextOutcome(3) {
+		for i, r := range o {
+			name, err := bag.Translate(r)
+			if err != nil {
+				t.Fatalf("DiceBag.Translate() error = %v", err)
+			}
+			if i == 2 && name != "[red]" && name != "[green]" {
+				t.Errorf("DiceBag.NextOutcome() third die = %v, want [red] or [green]", name)
+			}
+		}
+		outcomes++
+	}
+	if want, _ := bag.CountDistinctOutcomes(3); int64(outcomes) != want.Int64() {
+		t.Errorf("DiceBag.NextOutcome() generated %d outcomes, want %d", outcomes, want)
+	}
+}
+
+func TestDiceBag_NextOutcome(t *testing.T) {
+	bag := NewDiceBag([]int{4, 6})
+	if got, err := bag.NextOutcome(0); got != nil || err != nil {
+		t.Errorf("DiceBag.NextOutcome(0) = %v, %v, want nil, nil", got, err)
+	}
+	if got, err := bag.NextOutcome(1); len(got) != 1 || err != nil {
+		t.Errorf("DiceBag.NextOutcome(1) = %v, %v, want one roll", got, err)
+	}
+	if got, err := bag.NextOutcome(3); got != nil || !errors.Is(err, ErrTooManyDraws) {
+		t.Errorf("DiceBag.NextOutcome(3) = %v, %v, want nil, %v", got, err, ErrTooManyDraws)
+	}
+}
+
+func TestNewDiceBag_Translate(t *testing.T) {
+	bag := NewDiceBag([]int{4, 6})
+	if got, _ := bag.Translate(5); got != "[2]" {
+		t.Errorf("DiceBag.Translate(5) = %v, want [2]", got)
+	}
+}
+
+func TestDiceBag_Unordered(t *testing.T) {
+	tests := []struct {
+		name   string
+		bag    *DiceBag
+		k      int
+		count  int64
+		weight int64
+		prob   *big.Rat
+	}{
+		{"2d6", NewDiceBag([]int{6, 6}), 2, 15, 2, big.NewRat(30, 36)},
+		{"3d6", NewDiceBag([]int{6, 6, 6}), 3, 30, 3, big.NewRat(90, 216)},
+		{"d6-d8", NewDiceBag([]int{6, 8}), 2, 48, 1, big.NewRat(1, 1)},
+		{"d6-d8-d6", NewDiceBag([]int{6, 8, 6}), 3, 120, 2, big.NewRat(240, 288)},
+		{"3-coins", &NewCoinBag(3).DiceBag, 3, 2, 3, big.NewRat(6, 8)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.bag.SetDrawMode(Unordered)
+			if got, err := tt.bag.CountDistinctOutcomes(tt.k); err != nil || got.Cmp(big.NewInt(tt.count)) != 0 {
+				t.Errorf("DiceBag.CountDistinctOutcomes() = %v, want %v", got, tt.count)
+			}
+			if got := tt.bag.MappedProbability(tt.k); got.Cmp(tt.prob) != 0 {
+				t.Errorf("DiceBag.MappedProbability() = %v, want %v", got, tt.prob)
+			}
+			seen := make(map[string]bool)
+			for o, _ := tt.bag.NextOutcome(tt.k); o != nil; o, _ = tt.bag.NextOutcome(tt.k) {
+				if w := tt.bag.Weight(o); w.Cmp(big.NewInt(tt.weight)) != 0 {
+					t.Fatalf("DiceBag.Weight(%v) = %v, want %v", o, w, tt.weight)
+				}
+				sorted := append([]rune(nil), o...)
+				tt.bag.sortRoll(sorted)
+				if string(sorted) != string(o) || seen[string(o)] {
+					t.Fatalf("DiceBag.NextOutcome() = %v, want distinct sorted rolls", o)
+				}
+				seen[string(o)] = true
+			}
+			if int64(len(seen)) != tt.count {
+				t.Errorf("DiceBag.NextOutcome() generated %d outcomes, want %d", len(seen), tt.count)
+			}
+			src := rand.NewSource(1)
+			for i := 0; i < 20; i++ {
+				if o, err := tt.bag.RandomOutcome(tt.k, src); err != nil || !seen[string(o)] {
+					t.Errorf("DiceBag.RandomOutcome() = %v, want an enumerated outcome", o)
+				}
+			}
+		})
+	}
+}
+
+func TestDiceBag_Shuffle(t *testing.T) {
+	tests := []struct {
+		name string
+		mode DrawMode
+	}{
+		{name: "ordered", mode: Ordered},
+		{name: "unordered", mode: Unordered},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bag := NewDiceBag([]int{4, 6, 6})
+			bag.SetDrawMode(tt.mode)
+			before := make([][][]rune, bag.MaxDraws()+1)
+			for k := range before {
+				before[k] = collect(bag.Outcomes(k))
+			}
+			bag.Shuffle(rand.NewSource(42))
+			for k, outcomes := range before {
+				n, err := bag.CountDistinctOutcomes(k)
+				if err != nil || n.Cmp(big.NewInt(int64(len(outcomes)))) != 0 {
+					t.Errorf("DiceBag.CountDistinctOutcomes(%d) after Shuffle() = %v, %v, want %d", k, n, err, len(outcomes))
+				}
+				after := collect(bag.Outcomes(k))
+				seen := make(map[string]bool)
+				for _, o := range after {
+					seen[string(o)] = true
+				}
+				for _, o := range outcomes {
+					if !seen[string(o)] {
+						t.Errorf("DiceBag.Outcomes(%d) after Shuffle() lost outcome %v", k, o)
+					}
+				}
+				for i, o := range after {
+					got, err := bag.Index(o)
+					if err != nil || got.Cmp(big.NewInt(int64(i))) != 0 {
+						t.Errorf("DiceBag.Index(%v) after Shuffle() = %v, %v, want %d", o, got, err, i)
+					}
+					back, err := bag.Outcome(k, big.NewInt(int64(i)))
+					if err != nil || string(back) != string(o) {
+						t.Errorf("DiceBag.Outcome(%d, %d) after Shuffle() = %v, %v, want %v", k, i, back, err, o)
+					}
+				}
+			}
+			if k := bag.MaxDraws(); string(collect(bag.Outcomes(k))[0]) == string(before[k][0]) {
+				t.Errorf("DiceBag.Outcomes(%d) after Shuffle() starts with %v, want a shuffled order", k, before[k][0])
+			}
+		})
+	}
+}
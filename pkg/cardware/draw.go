@@ -0,0 +1,140 @@
+package cardware
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Draw is a single element of an outcome, tagged with the object that produced
+// it. The runes of an outcome are only meaningful to the object that drew them:
+// a deck draws card runes, while a dice bag numbers the faces of all of its dice
+// from zero. Once the outcomes of several objects are combined, the same rune
+// can mean different things, so an element should be tagged before it is
+// handled on its own.
+type Draw struct {
+	// Object is the position of the object that produced the draw among the
+	// sources of the RandomObject it was drawn from. A Composite's sources are
+	// its objects, with the objects of any nested Composite in its place; any
+	// other RandomObject is its own single source, numbered zero.
+	Object int
+	// Value is the rune drawn from the object.
+	Value rune
+}
+
+// Tagger is implemented by RandomObjects that combine the outcomes of other
+// RandomObjects.
+type Tagger interface {
+	// Tag tags each rune of an outcome with the source that produced it.
+	Tag(outcome []rune) ([]Draw, error)
+	// TranslateDraw translates a draw with the source that produced it.
+	TranslateDraw(d Draw) (string, error)
+}
+
+// Tag tags each rune of an outcome drawn from ro with the source that produced
+// it. If ro does not implement Tagger, every rune is tagged with source zero.
+func Tag(ro RandomObject, outcome []rune) ([]Draw, error) {
+	if t, ok := ro.(Tagger); ok {
+		return t.Tag(outcome)
+	}
+	draws := make([]Draw, len(outcome))
+	for i, r := range outcome {
+		draws[i] = Draw{Value: r}
+	}
+	return draws, nil
+}
+
+// Untag returns the outcome made up of the values of the draws.
+func Untag(draws []Draw) []rune {
+	outcome := make([]rune, len(draws))
+	for i, d := range draws {
+		outcome[i] = d.Value
+	}
+	return outcome
+}
+
+// TranslateDraw translates a draw from ro with the source that produced it.
+func TranslateDraw(ro RandomObject, d Draw) (string, error) {
+	if t, ok := ro.(Tagger); ok {
+		return t.TranslateDraw(d)
+	}
+	if d.Object != 0 {
+		return "", fmt.Errorf("source %d is %w", d.Object, ErrOutOfBounds)
+	}
+	return ro.Translate(d.Value)
+}
+
+// source returns the source of ro that produced a draw, or nil if ro has no
+// such source.
+func source(ro RandomObject, d Draw) RandomObject {
+	if n, ok := ro.(nester); ok {
+		sources := n.sources()
+		if d.Object < 0 || d.Object >= len(sources) {
+			return nil
+		}
+		return sources[d.Object]
+	}
+	if d.Object != 0 {
+		return nil
+	}
+	return ro
+}
+
+// TranslateOutcome translates every rune of an outcome drawn from ro, each with
+// the source that produced it.
+func TranslateOutcome(ro RandomObject, outcome []rune) ([]string, error) {
+	draws, err := Tag(ro, outcome)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(draws))
+	for i, d := range draws {
+		name, err := TranslateDraw(ro, d)
+		if err != nil {
+			return nil, fmt.Errorf("rune '%c' : %w", d.Value, err)
+		}
+		names[i] = name
+	}
+	return names, nil
+}
+
+// parser is implemented by RandomObjects that can read back the names of the
+// elements of their outcomes.
+type parser interface {
+	// parseOutcome returns the outcome whose elements have the given names.
+	parseOutcome(names []string) ([]rune, error)
+}
+
+// ParseOutcome reads back an outcome of len(names) draws from ro from the names
+// of its elements, as printed by TranslateOutcome, reversing it. Each name is
+// read by the source that draws in its position: card names as by Deck.Parse
+// and die and coin faces by their labels. Enclosing brackets are ignored, so
+// faces may be named with or without them. Hands and rolls of identical dice
+// drawn in Unordered mode may be named in any order.
+func ParseOutcome(ro RandomObject, names []string) ([]rune, error) {
+	draws, err := Tag(ro, make([]rune, len(names)))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]rune, 0, len(names))
+	for start := 0; start < len(draws); {
+		end := start + 1
+		for end < len(draws) && draws[end].Object == draws[start].Object {
+			end++
+		}
+		p, ok := source(ro, draws[start]).(parser)
+		if !ok {
+			return nil, fmt.Errorf("names of source %d cannot be read back", draws[start].Object)
+		}
+		part := make([]string, end-start)
+		for i, name := range names[start:end] {
+			part[i] = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(name), "["), "]")
+		}
+		o, err := p.parseOutcome(part)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, o...)
+		start = end
+	}
+	return out, nil
+}
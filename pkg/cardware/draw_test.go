@@ -0,0 +1,108 @@
+package cardware
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTag(t *testing.T) {
+	inner := NewComposite(NewCoinBag(1), NewDiceBag([]int{6}))
+	tests := []struct {
+		name    string
+		ro      RandomObject
+		outcome []rune
+		want    []Draw
+		names   []string
+	}{
+		{
+			name:    "deck",
+			ro:      NewStandardFrenchDeck(),
+			outcome: []rune{AceOfSpades, KingOfClubs},
+			want:    []Draw{{0, AceOfSpades}, {0, KingOfClubs}},
+			names:   []string{"A♠", "K♣"},
+		},
+		{
+			name:    "composite",
+			ro:      NewComposite(NewDiceBag([]int{6, 6}), NewTarotDeMarseilleDeck()),
+			outcome: []rune{0, 11, TheFool},
+			want:    []Draw{{0, 0}, {0, 11}, {1, TheFool}},
+			names:   []string{"[1]", "[6]", "0"},
+		},
+		{
+			name:    "nested",
+			ro:      NewComposite(inner, NewCoinBag(1)),
+			outcome: []rune{0, 2, 1},
+			want:    []Draw{{0, 0}, {1, 2}, {2, 1}},
+			names:   []string{"[H]", "[3]", "[T]"},
+		},
+		{
+			name:    "combined",
+			ro:      NewCombinedFrom(NewDiceBag([]int{200000}), NewStandardFrenchDeck()),
+			outcome: []rune{AceOfSpades, AceOfSpades},
+			want:    []Draw{{0, AceOfSpades}, {1, AceOfSpades}},
+			names:   []string{"[127138]", "A♠"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Tag(tt.ro, tt.outcome)
+			if err != nil {
+				t.Fatalf("Tag() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tag() = %v, want %v", got, tt.want)
+			}
+			if u := Untag(got); !reflect.DeepEqual(u, tt.outcome) {
+				t.Errorf("Untag() = %v, want %v", u, tt.outcome)
+			}
+			for i, d := range got {
+				if name, err := TranslateDraw(tt.ro, d); err != nil || name != tt.names[i] {
+					t.Errorf("TranslateDraw(%v) = %v, %v, want %v", d, name, err, tt.names[i])
+				}
+			}
+			if names, err := TranslateOutcome(tt.ro, tt.outcome); err != nil || !reflect.DeepEqual(names, tt.names) {
+				t.Errorf("TranslateOutcome() = %v, %v, want %v", names, err, tt.names)
+			}
+		})
+	}
+}
+
+func TestTag_Invalid(t *testing.T) {
+	c := NewComposite(NewCoinBag(1), NewTarotDeMarseilleDeck())
+	if _, err := Tag(c, make([]rune, 80)); !errors.Is(err, ErrTooManyDraws) {
+		t.Errorf("Tag() error = %v, want %v", err, ErrTooManyDraws)
+	}
+	for _, d := range []Draw{{-1, 0}, {2, 0}} {
+		if _, err := TranslateDraw(c, d); !errors.Is(err, ErrOutOfBounds) {
+			t.Errorf("TranslateDraw(%v) error = %v, want %v", d, err, ErrOutOfBounds)
+		}
+	}
+	if _, err := TranslateDraw(NewStandardFrenchDeck(), Draw{1, AceOfSpades}); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("TranslateDraw() error = %v, want %v", err, ErrOutOfBounds)
+	}
+}
+
+func TestComposite_TranslateAmbiguous(t *testing.T) {
+	c := NewCombinedFrom(NewDiceBag([]int{200000}), NewStandardFrenchDeck())
+	if got, err := c.Translate(5); err != nil || got != "[6]" {
+		t.Errorf("Combined.Translate(5) = %v, %v, want [6]", got, err)
+	}
+	if _, err := c.Translate(AceOfSpades); !errors.Is(err, ErrAmbiguous) {
+		t.Errorf("Combined.Translate(AceOfSpades) error = %v, want %v", err, ErrAmbiguous)
+	}
+	if _, err := c.Translate(300000); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("Combined.Translate(300000) error = %v, want %v", err, ErrOutOfBounds)
+	}
+}
+
+func TestComposite_TranslateSameName(t *testing.T) {
+	c := NewComposite(NewStandardFrenchDeck(), NewStandardFrenchDeck())
+	if got, err := c.Translate(AceOfSpades); err != nil || got != "A♠" {
+		t.Errorf("Composite.Translate(AceOfSpades) = %v, %v, want A♠", got, err)
+	}
+	nested := NewComposite(NewDiceBag([]int{6}), c)
+	if got, err := nested.Translate(KingOfClubs); err != nil || got != "K♣" {
+		t.Errorf("Composite.Translate(KingOfClubs) = %v, %v, want K♣", got, err)
+	}
+}
@@ -0,0 +1,149 @@
+package cardware
+
+import (
+	"math"
+	"math/big"
+	"sort"
+)
+
+// DrawMode determines how the cards of an outcome are drawn from a deck.
+type DrawMode int
+
+const (
+	// Ordered draws deal cards one at a time. The order in which the cards are
+	// dealt is part of the outcome.
+	Ordered DrawMode = iota
+	// Unordered draws deal a hand of cards at once. The order of the cards does
+	// not matter, so outcomes are sorted with SortHand.
+	Unordered
+	// WithReplacement draws deal cards one at a time, returning each card to
+	// the deck and reshuffling before the next is dealt. The same card may be
+	// drawn more than once, so k draws from n distinct cards have n^k outcomes.
+	WithReplacement
+)
+
+// UnlimitedDraws is the number of draws MaxDraws reports for a deck drawn
+// WithReplacement, which never runs out of cards.
+const UnlimitedDraws = math.MaxInt32
+
+// String returns the name of the draw mode.
+func (m DrawMode) String() string {
+	switch m {
+	case Ordered:
+		return "ordered"
+	case Unordered:
+		return "unordered"
+	case WithReplacement:
+		return "with replacement"
+	}
+	return "unknown"
+}
+
+// DrawMode returns the deck's draw mode.
+func (d *Deck) DrawMode() DrawMode {
+	return d.mode
+}
+
+// SetDrawMode sets how cards are drawn from the deck and restarts enumeration.
+func (d *Deck) SetDrawMode(m DrawMode) {
+	d.mode = m
+	d.next = nil
+}
+
+// SortHand sorts the cards of an unordered hand into rune order, so that every
+// arrangement of the same cards has the same table entry.
+func SortHand(hand []rune) {
+	sort.Slice(hand, func(i, j int) bool { return hand[i] < hand[j] })
+}
+
+// countMultisetCombinations counts the distinct hands of k elements drawn from a
+// multiset with the given multiplicities.
+func countMultisetCombinations(counts []int, k int) *big.Int {
+	// ways[j] is the number of distinct hands of size j using the
+	// multiplicities considered so far.
+	ways := make([]*big.Int, k+1)
+	ways[0] = big.NewInt(1)
+	for j := 1; j <= k; j++ {
+		ways[j] = big.NewInt(0)
+	}
+	for _, m := range counts {
+		next := make([]*big.Int, k+1)
+		for j := 0; j <= k; j++ {
+			next[j] = big.NewInt(0)
+			// take t copies of this element
+			for t := 0; t <= m && t <= j; t++ {
+				next[j].Add(next[j], ways[j-t])
+			}
+		}
+		ways = next
+	}
+	return ways[k]
+}
+
+// handGenerator enumerates the distinct hands of k elements drawn from a
+// multiset. Each hand is a nondecreasing sequence of element indices, and hands
+// are generated in lexicographic order.
+type handGenerator struct {
+	avail []int
+	hand  []int
+	begun bool
+}
+
+func newHandGenerator(counts []int, k int) *handGenerator {
+	avail := make([]int, len(counts))
+	copy(avail, counts)
+	return &handGenerator{avail: avail, hand: make([]int, k)}
+}
+
+// fill places the smallest available elements not less than t in positions
+// from onward. If there are not enough, it leaves the hand unchanged and
+// returns false.
+func (g *handGenerator) fill(from, t int) bool {
+	for i := from; i < len(g.hand); i++ {
+		for t < len(g.avail) && g.avail[t] == 0 {
+			t++
+		}
+		if t == len(g.avail) {
+			for j := from; j < i; j++ {
+				g.avail[g.hand[j]]++
+			}
+			return false
+		}
+		g.hand[i] = t
+		g.avail[t]--
+	}
+	return true
+}
+
+// Next advances to the next hand, returning false when all hands have been
+// generated.
+func (g *handGenerator) Next() bool {
+	if !g.begun {
+		g.begun = true
+		return g.fill(0, 0)
+	}
+	for i := len(g.hand) - 1; i >= 0; i-- {
+		g.avail[g.hand[i]]++
+		for t := g.hand[i] + 1; t < len(g.avail); t++ {
+			if g.avail[t] == 0 {
+				continue
+			}
+			g.avail[t]--
+			if g.fill(i+1, t) {
+				g.hand[i] = t
+				return true
+			}
+			// larger elements leave even fewer to fill the rest of the hand
+			g.avail[t]++
+			break
+		}
+	}
+	return false
+}
+
+// Hand returns the current hand of element indices.
+func (g *handGenerator) Hand() []int {
+	out := make([]int, len(g.hand))
+	copy(out, g.hand)
+	return out
+}
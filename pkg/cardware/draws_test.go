@@ -0,0 +1,153 @@
+package cardware
+
+import (
+	"math/big"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestDeck_Unordered(t *testing.T) {
+	tests := []struct {
+		name string
+		d    *Deck
+		k    int
+		want int64
+	}{
+		{
+			"zero",
+			NewStandardFrenchDeck(),
+			0,
+			1,
+		},
+		{
+			"standard-3",
+			NewStandardFrenchDeck(),
+			3,
+			22100,
+		},
+		{
+			"standard-52",
+			NewStandardFrenchDeck(),
+			52,
+			1,
+		},
+		{
+			"pinochle-2",
+			NewPinochleDeck(),
+			2,
+			24*23/2 + 24,
+		},
+		{
+			"pinochle-3",
+			NewPinochleDeck(),
+			3,
+			24*23*22/6 + 24*23,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.d.SetDrawMode(Unordered)
+			if got, err := tt.d.CountDistinctOutcomes(tt.k); err != nil || got.Cmp(big.NewInt(tt.want)) != 0 {
+				t.Errorf("Deck.CountDistinctOutcomes() = %v, want %v", got, tt.want)
+			}
+			seen := make(map[string]bool)
+			for _, o := range collect(tt.d.Outcomes(tt.k)) {
+				if !sort.SliceIsSorted(o, func(i, j int) bool { return o[i] < o[j] }) {
+					t.Fatalf("Deck.Outcomes() = %v, want sorted hand", o)
+				}
+				if seen[string(o)] {
+					t.Fatalf("Deck.Outcomes() repeated %v", o)
+				}
+				seen[string(o)] = true
+			}
+			if int64(len(seen)) != tt.want {
+				t.Errorf("Deck.Outcomes() enumerated %d hands, want %d", len(seen), tt.want)
+			}
+		})
+	}
+}
+
+func TestDeck_UnorderedRandomOutcome(t *testing.T) {
+	d := NewStandardFrenchDeck()
+	d.SetDrawMode(Unordered)
+	src := rand.NewSource(1)
+	for i := 0; i < 100; i++ {
+		o, err := d.RandomOutcome(5, src)
+		if err != nil {
+			t.Fatalf("Deck.RandomOutcome() error = %v", err)
+		}
+		if !sort.SliceIsSorted(o, func(i, j int) bool { return o[i] < o[j] }) {
+			t.Fatalf("Deck.RandomOutcome() = %v, want sorted hand", o)
+		}
+	}
+}
+
+func TestDeck_WithReplacement(t *testing.T) {
+	tests := []struct {
+		name string
+		d    *Deck
+		k    int
+		want int64
+	}{
+		{
+			"zero",
+			NewStandardFrenchDeck(),
+			0,
+			1,
+		},
+		{
+			"standard-2",
+			NewStandardFrenchDeck(),
+			2,
+			52 * 52,
+		},
+		{
+			"tarot-2",
+			NewTarotDeMarseilleDeck(),
+			2,
+			78 * 78,
+		},
+		{
+			"pinochle-2",
+			NewPinochleDeck(),
+			2,
+			24 * 24,
+		},
+		{
+			"more-than-deck",
+			&Deck{cards: []Card{'A', 'B', 'C'}},
+			4,
+			3 * 3 * 3 * 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.d.SetDrawMode(WithReplacement)
+			if got := tt.d.MaxDraws(); got != UnlimitedDraws {
+				t.Errorf("Deck.MaxDraws() = %v, want %v", got, UnlimitedDraws)
+			}
+			if got := NewComposite(tt.d, tt.d).MaxDraws(); got != UnlimitedDraws {
+				t.Errorf("Composite.MaxDraws() = %v, want %v", got, UnlimitedDraws)
+			}
+			if got, err := tt.d.CountDistinctOutcomes(tt.k); err != nil || got.Cmp(big.NewInt(tt.want)) != 0 {
+				t.Errorf("Deck.CountDistinctOutcomes() = %v, want %v", got, tt.want)
+			}
+			seen := make(map[string]bool)
+			for _, o := range collect(tt.d.Outcomes(tt.k)) {
+				if len(o) != tt.k || seen[string(o)] {
+					t.Fatalf("Deck.Outcomes() = %v, want distinct outcomes of %d cards", o, tt.k)
+				}
+				seen[string(o)] = true
+			}
+			if int64(len(seen)) != tt.want {
+				t.Errorf("Deck.Outcomes() enumerated %d outcomes, want %d", len(seen), tt.want)
+			}
+			if tt.k > 0 {
+				if o, err := tt.d.RandomOutcome(tt.k, rand.NewSource(1)); err != nil || !seen[string(o)] {
+					t.Errorf("Deck.RandomOutcome() = %v, want an enumerated outcome", o)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package cardware
+
+import (
+	"bufio"
+	"crypto/hmac"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SeedSize is the number of bytes in a seed for a reproducible table (256 bits).
+const SeedSize = 32
+
+// HMACDRBG is a deterministic random bit generator implementing HMAC_DRBG with
+// SHA-256 as specified in NIST SP 800-90A, without reseeding, personalization
+// strings or additional input. Two generators instantiated with the same seed
+// produce identical output, so a recorded seed is enough to regenerate a table.
+//
+// HMACDRBG implements rand.Source64. Every call to Uint64 or Int63 is a single
+// generate request for eight bytes.
+type HMACDRBG struct {
+	k []byte
+	v []byte
+}
+
+// NewHMACDRBG instantiates an HMAC_DRBG from the given seed material.
+func NewHMACDRBG(seed []byte) *HMACDRBG {
+	d := &HMACDRBG{}
+	d.instantiate(seed)
+	return d
+}
+
+func (d *HMACDRBG) instantiate(seed []byte) {
+	d.k = make([]byte, sha256.Size)
+	d.v = make([]byte, sha256.Size)
+	for i := range d.v {
+		d.v[i] = 0x01
+	}
+	d.update(seed)
+}
+
+func (d *HMACDRBG) hmac(data ...[]byte) []byte {
+	h := hmac.New(sha256.New, d.k)
+	for _, b := range data {
+		h.Write(b)
+	}
+	return h.Sum(nil)
+}
+
+func (d *HMACDRBG) update(data []byte) {
+	d.k = d.hmac(d.v, []byte{0x00}, data)
+	d.v = d.hmac(d.v)
+	if len(data) == 0 {
+		return
+	}
+	d.k = d.hmac(d.v, []byte{0x01}, data)
+	d.v = d.hmac(d.v)
+}
+
+// Read fills p with the output of a single generate request. It never returns an error.
+func (d *HMACDRBG) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		d.v = d.hmac(d.v)
+		n += copy(p[n:], d.v)
+	}
+	d.update(nil)
+	return n, nil
+}
+
+// Seed implements rand.Source interface. It reinstantiates the generator using
+// the big-endian bytes of seed as seed material. Prefer NewHMACDRBG with a full
+// SeedSize seed.
+func (d *HMACDRBG) Seed(seed int64) {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(seed))
+	d.instantiate(b)
+}
+
+// Int63 implements rand.Source interface.
+func (d *HMACDRBG) Int63() int64 {
+	return int64(d.Uint64() & ^uint64(1<<63))
+}
+
+// Uint64 implements rand.Source64 interface.
+func (d *HMACDRBG) Uint64() uint64 {
+	b := make([]byte, 8)
+	d.Read(b)
+	return binary.BigEndian.Uint64(b)
+}
+
+// NewSeed draws a fresh SeedSize-byte seed from the system's cryptographically
+// secure random number generator.
+func NewSeed() ([]byte, error) {
+	seed := make([]byte, SeedSize)
+	if _, err := io.ReadFull(crand.Reader, seed); err != nil {
+		return nil, err
+	}
+	return seed, nil
+}
+
+// ReadSeed reads a hex-encoded SeedSize-byte seed, as written by WriteSeed.
+func ReadSeed(r io.Reader) ([]byte, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		t := strings.TrimSpace(scanner.Text())
+		if len(t) == 0 {
+			continue
+		}
+		seed, err := hex.DecodeString(t)
+		if err != nil {
+			return nil, fmt.Errorf("seed is not valid hex : %v", err)
+		}
+		if len(seed) != SeedSize {
+			return nil, fmt.Errorf("seed has %d bits, expected %d", len(seed)*8, SeedSize*8)
+		}
+		return seed, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("seed not found")
+}
+
+// WriteSeed writes a seed as a single line of hex.
+func WriteSeed(w io.Writer, seed []byte) error {
+	_, err := fmt.Fprintln(w, hex.EncodeToString(seed))
+	return err
+}
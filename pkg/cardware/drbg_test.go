@@ -0,0 +1,78 @@
+package cardware
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHMACDRBG_Read(t *testing.T) {
+	// NIST CAVP HMAC_DRBG test vector: SHA-256, no prediction resistance,
+	// no personalization string or additional input, COUNT = 0.
+	entropy, _ := hex.DecodeString("ca851911349384bffe89de1cbdc46e6831e44d34a4fb935ee285dd14b71a7488")
+	nonce, _ := hex.DecodeString("659ba96c601dc69fc902940805ec0ca8")
+	want, _ := hex.DecodeString("e528e9abf2dece54d47c7e75e5fe302149f817ea9fb4bee6f4199697d04d5b89d54fbb978a15b5c443c9ec21036d2460b6f73ebad0dc2aba6e624abf07745bc107694bb7547bb0995f70de25d6b29e2d3011bb19d27676c07162c8b5ccde0668961df86803482cb37ed6d5c0bb8d50cf1f50d476aa0458bdaba806f48be9dcb8")
+
+	d := NewHMACDRBG(append(entropy, nonce...))
+	got := make([]byte, len(want))
+	d.Read(got)
+	d.Read(got)
+	if !bytes.Equal(got, want) {
+		t.Errorf("HMACDRBG.Read() = %x, want %x", got, want)
+	}
+}
+
+func TestReadSeed(t *testing.T) {
+	seed := make([]byte, SeedSize)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	var buf bytes.Buffer
+	if err := WriteSeed(&buf, seed); err != nil {
+		t.Fatalf("WriteSeed() error = %v", err)
+	}
+	got, err := ReadSeed(&buf)
+	if err != nil {
+		t.Fatalf("ReadSeed() error = %v", err)
+	}
+	if !bytes.Equal(got, seed) {
+		t.Errorf("ReadSeed() = %x, want %x", got, seed)
+	}
+
+	if _, err := ReadSeed(bytes.NewBufferString("abcd\n")); err == nil {
+		t.Errorf("ReadSeed() of short seed succeeded")
+	}
+}
+
+func TestNewSource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cardware")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "seed")
+
+	if _, seed, err := NewSource("", false); seed != nil || err != nil {
+		t.Errorf("NewSource() = %x, %v, want no seed", seed, err)
+	}
+	src, seed, err := NewSource("", true)
+	if err != nil || len(seed) != SeedSize {
+		t.Fatalf("NewSource() = %x, %v, want a %d-byte seed", seed, err, SeedSize)
+	}
+	if _, err := os.Stat(name); !os.IsNotExist(err) {
+		t.Fatalf("NewSource() wrote the seed before WriteSeedFile()")
+	}
+	if err := WriteSeedFile(name, seed); err != nil {
+		t.Fatalf("WriteSeedFile() error = %v", err)
+	}
+	replay, got, err := NewSource(name, false)
+	if err != nil || !bytes.Equal(got, seed) {
+		t.Fatalf("NewSource(%s) = %x, %v, want %x", name, got, err, seed)
+	}
+	if a, b := src.Int63(), replay.Int63(); a != b {
+		t.Errorf("replayed source drew %v, want %v", b, a)
+	}
+}
@@ -0,0 +1,136 @@
+package cardware
+
+import (
+	"math"
+	"math/big"
+)
+
+// Entropy accounts for the bits of entropy delivered by a generated table.
+//
+// A lookup draws from the RandomObject used to build the table. If the table
+// maps fewer words than the object has distinct outcomes, unmapped outcomes are
+// redrawn, so every mapped word remains equally likely.
+type Entropy struct {
+	// Outcomes is the number of distinct outcomes of a single lookup.
+	Outcomes *big.Int
+	// Words is the number of words actually mapped to outcomes in the table.
+	Words int
+	// Symbols is the number of distinct symbols in the symbol table, or zero if
+	// no symbol table is used.
+	Symbols int
+	// MaxProbability is the probability that a lookup lands on the most likely
+	// mapped outcome, or nil if every mapped outcome is equally likely. Mapped
+	// outcomes are not equally likely if the same card can be drawn from more
+	// than one copy in the deck, in which case only the min-entropy of a lookup
+	// is counted.
+	MaxProbability *big.Rat
+	// Capitals reports whether the table has a capital rule: one of the deck's
+	// two colors, chosen at random when the table is generated and printed with
+	// it.
+	Capitals bool
+	// CapitalDrawn reports whether the color that decides capitalization is
+	// drawn for each word on its own, independently of the draws already
+	// counted in the lookup and symbol bits. Only then does the capital rule
+	// add a bit per word; otherwise capitalization follows from cards already
+	// drawn and adds nothing.
+	CapitalDrawn bool
+}
+
+// NewEntropy builds an Entropy for a table of words mapped to the first outcomes
+// of k draws from ro, in the order enumerated by ro.Outcomes. It returns an
+// error if k draws cannot be made from ro.
+func NewEntropy(ro RandomObject, k int, words int) (Entropy, error) {
+	n, err := ro.CountDistinctOutcomes(k)
+	if err != nil {
+		return Entropy{}, err
+	}
+	e := Entropy{Outcomes: n, Words: words}
+	w, ok := ro.(weigher)
+	if !ok || w.uniform() {
+		return e, nil
+	}
+	it, err := ro.Outcomes(k)
+	if err != nil {
+		return Entropy{}, err
+	}
+	max, total := new(big.Int), new(big.Int)
+	mapped := int64(0)
+	for ; mapped < int64(words); mapped++ {
+		o := it.Next()
+		if o == nil {
+			break
+		}
+		x := w.Weight(o)
+		if x.Cmp(max) > 0 {
+			max = x
+		}
+		total.Add(total, x)
+	}
+	if mapped > 0 && new(big.Int).Mul(max, big.NewInt(mapped)).Cmp(total) != 0 {
+		e.MaxProbability = new(big.Rat).SetFrac(max, total)
+	}
+	return e, nil
+}
+
+// weigher is implemented by RandomObjects whose distinct outcomes may not all
+// be equally likely.
+type weigher interface {
+	// uniform reports whether every distinct outcome is equally likely.
+	uniform() bool
+	// Weight returns the number of ways the outcome can be drawn.
+	Weight(outcome []rune) *big.Int
+}
+
+// LookupBits returns the bits of entropy delivered by a single table lookup.
+func (e Entropy) LookupBits() float64 {
+	if e.Words <= 0 || e.Outcomes == nil || e.Outcomes.Sign() <= 0 {
+		return 0
+	}
+	if e.MaxProbability != nil {
+		return log2(e.MaxProbability.Denom()) - log2(e.MaxProbability.Num())
+	}
+	n := big.NewInt(int64(e.Words))
+	if e.Outcomes.Cmp(n) < 0 {
+		n = e.Outcomes
+	}
+	return log2(n)
+}
+
+// CapitalBits returns the bits of entropy added to each word by the capital
+// rule: one bit if the color that decides capitalization is drawn on its own,
+// with even odds, and none otherwise.
+func (e Entropy) CapitalBits() float64 {
+	if !e.Capitals || !e.CapitalDrawn {
+		return 0
+	}
+	return 1
+}
+
+// WordBits returns the bits of entropy delivered by a single word, including
+// any bit added by the capital rule.
+func (e Entropy) WordBits() float64 {
+	return e.LookupBits() + e.CapitalBits()
+}
+
+// SymbolBits returns the bits of entropy delivered by a single symbol table lookup.
+func (e Entropy) SymbolBits() float64 {
+	if e.Symbols <= 0 {
+		return 0
+	}
+	return math.Log2(float64(e.Symbols))
+}
+
+// PassphraseBits returns the bits of entropy delivered by a passphrase built
+// from the given number of words and symbols.
+func (e Entropy) PassphraseBits(words, symbols int) float64 {
+	return float64(words)*e.WordBits() + float64(symbols)*e.SymbolBits()
+}
+
+// log2 computes the base-2 logarithm of a positive big integer without
+// overflowing a float64.
+func log2(x *big.Int) float64 {
+	mant := new(big.Float)
+	exp := new(big.Float).SetInt(x).MantExp(mant)
+	m, _ := mant.Float64()
+	return math.Log2(m) + float64(exp)
+}
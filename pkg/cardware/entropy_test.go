@@ -0,0 +1,158 @@
+package cardware
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestEntropy_LookupBits(t *testing.T) {
+	tests := []struct {
+		name string
+		e    Entropy
+		want float64
+	}{
+		{
+			name: "no-words",
+			e:    Entropy{Outcomes: big.NewInt(52), Words: 0},
+			want: 0,
+		},
+		{
+			name: "all-mapped",
+			e:    Entropy{Outcomes: big.NewInt(1024), Words: 2048},
+			want: 10,
+		},
+		{
+			name: "partially-mapped",
+			e:    Entropy{Outcomes: big.NewInt(132600), Words: 8192},
+			want: 13,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.LookupBits(); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Entropy.LookupBits() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewEntropy(t *testing.T) {
+	e, err := NewEntropy(NewStandardFrenchDeck(), 3, 1000000)
+	if err != nil {
+		t.Fatalf("NewEntropy() error = %v", err)
+	}
+	if got, want := e.LookupBits(), math.Log2(132600); math.Abs(got-want) > 1e-9 {
+		t.Errorf("Entropy.LookupBits() = %v, want %v", got, want)
+	}
+	if _, err := NewEntropy(NewStandardFrenchDeck(), 53, 1000000); !errors.Is(err, ErrTooManyDraws) {
+		t.Errorf("NewEntropy() error = %v, want %v", err, ErrTooManyDraws)
+	}
+}
+
+func TestNewEntropy_Duplicates(t *testing.T) {
+	unordered := NewPinochleDeck()
+	unordered.SetDrawMode(Unordered)
+	replaced := NewRepeatedDeck(NewTarotMajorArcanaDeck(), 3)
+	replaced.SetDrawMode(WithReplacement)
+	tests := []struct {
+		name  string
+		ro    RandomObject
+		k     int
+		words int
+		want  float64
+	}{
+		{
+			name:  "pinochle-ordered",
+			ro:    NewPinochleDeck(),
+			k:     2,
+			words: 1000000,
+			want:  math.Log2(48 * 47 / 4),
+		},
+		{
+			name:  "pinochle-unordered",
+			ro:    unordered,
+			k:     2,
+			words: 1000000,
+			want:  math.Log2(48 * 47 / 2 / 4),
+		},
+		{
+			name:  "with-replacement",
+			ro:    replaced,
+			k:     2,
+			words: 1000000,
+			want:  math.Log2(22 * 22),
+		},
+		{
+			name:  "composite",
+			ro:    NewComposite(NewDiceBag([]int{6}), NewPinochleDeck()),
+			k:     3,
+			words: 1000000,
+			want:  math.Log2(6 * 48 * 47 / 4),
+		},
+		{
+			name:  "one-word",
+			ro:    NewPinochleDeck(),
+			k:     2,
+			words: 1,
+			want:  0,
+		},
+		{
+			name:  "no-duplicates",
+			ro:    NewStandardFrenchDeck(),
+			k:     2,
+			words: 1000,
+			want:  math.Log2(1000),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := NewEntropy(tt.ro, tt.k, tt.words)
+			if err != nil {
+				t.Fatalf("NewEntropy() error = %v", err)
+			}
+			if got := e.LookupBits(); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Entropy.LookupBits() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEntropy_PassphraseBits(t *testing.T) {
+	tests := []struct {
+		name       string
+		e          Entropy
+		capital    float64
+		word       float64
+		passphrase float64
+	}{
+		{"no-capitals", Entropy{Outcomes: big.NewInt(4096), Words: 4096, Symbols: 32}, 0, 12, 4*12. + 3*5.},
+		{"capital-drawn", Entropy{Outcomes: big.NewInt(4096), Words: 4096, Symbols: 32, Capitals: true, CapitalDrawn: true}, 1, 13, 4*13. + 3*5.},
+		{"capital-from-outcome", Entropy{Outcomes: big.NewInt(4096), Words: 4096, Symbols: 32, Capitals: true}, 0, 12, 4*12. + 3*5.},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.CapitalBits(); got != tt.capital {
+				t.Errorf("Entropy.CapitalBits() = %v, want %v", got, tt.capital)
+			}
+			if got := tt.e.WordBits(); got != tt.word {
+				t.Errorf("Entropy.WordBits() = %v, want %v", got, tt.word)
+			}
+			if got := tt.e.PassphraseBits(4, 3); got != tt.passphrase {
+				t.Errorf("Entropy.PassphraseBits() = %v, want %v", got, tt.passphrase)
+			}
+		})
+	}
+}
+
+func TestLog2(t *testing.T) {
+	fac78 := big.NewInt(0).MulRange(1, 78)
+	want := 0.
+	for i := 2; i <= 78; i++ {
+		want += math.Log2(float64(i))
+	}
+	if got := log2(fac78); math.Abs(got-want) > 1e-9 {
+		t.Errorf("log2(78!) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,48 @@
+package cardware
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Errors returned by RandomObject methods, deck builders, and the device
+// registry. They are wrapped with details of the request that failed, so
+// compare against them with errors.Is.
+var (
+	// ErrTooManyDraws means more draws were requested than the object allows.
+	ErrTooManyDraws = errors.New("too many draws")
+	// ErrNegativeDraws means a negative number of draws was requested.
+	ErrNegativeDraws = errors.New("negative number of draws")
+	// ErrOutOfBounds means a rune, face, or index is not one the object can
+	// produce, for instance a rune passed to Translate that is not a card in
+	// the deck.
+	ErrOutOfBounds = errors.New("out of bounds")
+	// ErrAmbiguous means a rune could have been drawn from more than one of
+	// the objects making up a Composite.
+	ErrAmbiguous = errors.New("ambiguous rune")
+	// ErrEmptyDeck means a deck would be built with no cards.
+	ErrEmptyDeck = errors.New("deck has no cards")
+	// ErrNotRegistered means no deck or die is registered with a name.
+	ErrNotRegistered = errors.New("not registered")
+)
+
+// checkDraws returns an error if k draws cannot be made from an object that
+// allows at most max.
+func checkDraws(k, max int) error {
+	if k > max {
+		return fmt.Errorf("%d draws from at most %d: %w", k, max, ErrTooManyDraws)
+	}
+	if k < 0 {
+		return fmt.Errorf("%d draws: %w", k, ErrNegativeDraws)
+	}
+	return nil
+}
+
+// checkIndex returns an error if index is not in [0, n).
+func checkIndex(index, n *big.Int) error {
+	if index.Sign() < 0 || index.Cmp(n) >= 0 {
+		return fmt.Errorf("index %v of %v outcomes is %w", index, n, ErrOutOfBounds)
+	}
+	return nil
+}
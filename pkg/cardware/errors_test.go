@@ -0,0 +1,91 @@
+package cardware
+
+import (
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestRandomObject_Errors(t *testing.T) {
+	objects := []struct {
+		name string
+		ro   RandomObject
+	}{
+		{"deck", NewStandardFrenchDeck()},
+		{"pinochle", NewPinochleDeck()},
+		{"dice", NewDiceBag([]int{6, 6})},
+		{"composite", NewComposite(NewCoinBag(1), NewTarotDeMarseilleDeck())},
+		{"combined", NewCombined([]int{6})},
+	}
+	draws := []struct {
+		name    string
+		k       int
+		wantErr error
+	}{
+		{"too-many", 1, ErrTooManyDraws},
+		{"negative", -1, ErrNegativeDraws},
+	}
+	for _, o := range objects {
+		for _, d := range draws {
+			t.Run(o.name+"-"+d.name, func(t *testing.T) {
+				k := d.k
+				if k > 0 {
+					k += o.ro.MaxDraws()
+				}
+				if _, err := o.ro.CountDistinctOutcomes(k); !errors.Is(err, d.wantErr) {
+					t.Errorf("CountDistinctOutcomes(%d) error = %v, want %v", k, err, d.wantErr)
+				}
+				if _, err := o.ro.Outcomes(k); !errors.Is(err, d.wantErr) {
+					t.Errorf("Outcomes(%d) error = %v, want %v", k, err, d.wantErr)
+				}
+				if _, err := o.ro.RandomOutcome(k, rand.NewSource(1)); !errors.Is(err, d.wantErr) {
+					t.Errorf("RandomOutcome(%d) error = %v, want %v", k, err, d.wantErr)
+				}
+				if _, err := o.ro.Outcome(k, big.NewInt(0)); !errors.Is(err, d.wantErr) {
+					t.Errorf("Outcome(%d) error = %v, want %v", k, err, d.wantErr)
+				}
+			})
+		}
+		t.Run(o.name+"-index", func(t *testing.T) {
+			n, err := o.ro.CountDistinctOutcomes(1)
+			if err != nil {
+				t.Fatalf("CountDistinctOutcomes(1) error = %v", err)
+			}
+			for _, index := range []*big.Int{big.NewInt(-1), n} {
+				if _, err := o.ro.Outcome(1, index); !errors.Is(err, ErrOutOfBounds) {
+					t.Errorf("Outcome(1, %v) error = %v, want %v", index, err, ErrOutOfBounds)
+				}
+			}
+		})
+	}
+}
+
+func TestTranslate_OutOfBounds(t *testing.T) {
+	custom, err := NewCustomDeck(DeckDefinition{Name: "tiny", Trumps: []TrumpDefinition{{Name: "Only", Rune: "a"}}})
+	if err != nil {
+		t.Fatalf("NewCustomDeck() error = %v", err)
+	}
+	tests := []struct {
+		name string
+		ro   RandomObject
+		r    rune
+	}{
+		{"french", NewStandardFrenchDeck(), TheFool},
+		{"french-knight", NewStandardFrenchDeck(), AceOfSpades + 11},
+		{"tarot", NewTarotDeMarseilleDeck(), 'a'},
+		{"custom", custom, 'b'},
+		{"dice", NewDiceBag([]int{6}), 6},
+		{"composite", NewComposite(NewDiceBag([]int{6}), NewStandardFrenchDeck()), TheFool},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.ro.Translate(tt.r); !errors.Is(err, ErrOutOfBounds) {
+				t.Errorf("Translate(%q) error = %v, want %v", tt.r, err, ErrOutOfBounds)
+			}
+		})
+	}
+	if _, err := TranslateOutcome(NewStandardFrenchDeck(), []rune{TheFool}); !errors.Is(err, ErrOutOfBounds) {
+		t.Errorf("TranslateOutcome() error = %v, want %v", err, ErrOutOfBounds)
+	}
+}
@@ -0,0 +1,123 @@
+package cardware
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ExportCard is a single element of an outcome in an exported table. Name is
+// the card's name or the face's label, without the brackets that dice and coin
+// faces are printed in. Rune is the Unicode card drawn, and is empty for
+// elements not drawn from a deck, such as the faces of dice and coins.
+type ExportCard struct {
+	Name string `json:"name"`
+	Rune string `json:"rune,omitempty"`
+}
+
+// ExportEntry is a single outcome-word pair in an exported table.
+type ExportEntry struct {
+	Cards []ExportCard `json:"cards"`
+	Word  string       `json:"word"`
+}
+
+// ExportSymbol is a single cell of the symbol table in an exported table.
+type ExportSymbol struct {
+	Value  string `json:"value"`
+	Color  string `json:"color"`
+	Symbol string `json:"symbol"`
+}
+
+// Export is a card-word table with its optional symbol table and capital rule,
+// in a form suitable for writing as JSON or CSV.
+type Export struct {
+	Entries []ExportEntry  `json:"entries"`
+	Symbols []ExportSymbol `json:"symbols,omitempty"`
+	Capital string         `json:"capital,omitempty"`
+}
+
+// Add appends an entry mapping the outcome to the word, translating the
+// outcome with ro.
+func (e *Export) Add(ro RandomObject, outcome []rune, word string) error {
+	draws, err := Tag(ro, outcome)
+	if err != nil {
+		return err
+	}
+	cards := make([]ExportCard, len(draws))
+	for i, d := range draws {
+		name, err := TranslateDraw(ro, d)
+		if err != nil {
+			return fmt.Errorf("rune '%c' : %w", d.Value, err)
+		}
+		cards[i] = ExportCard{Name: name}
+		switch o := source(ro, d).(type) {
+		case *Deck:
+			cards[i].Rune = string(d.Value)
+		case labeler:
+			if cards[i].Name, err = o.label(d.Value); err != nil {
+				return fmt.Errorf("rune '%c' : %w", d.Value, err)
+			}
+		}
+	}
+	e.Entries = append(e.Entries, ExportEntry{Cards: cards, Word: word})
+	return nil
+}
+
+// labeler is implemented by RandomObjects whose translations decorate a plain
+// label, such as dice bags.
+type labeler interface {
+	label(r rune) (string, error)
+}
+
+// WriteJSON writes the table as a single JSON object.
+func (e *Export) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(e)
+}
+
+// WriteCSV writes the table as CSV. The entries come first, with one column per
+// card followed by the word. The symbol table (value, color and symbol columns)
+// and the capital rule follow as separate sections, each preceded by an empty
+// line and a header row.
+func (e *Export) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	nCards := 0
+	for _, entry := range e.Entries {
+		if len(entry.Cards) > nCards {
+			nCards = len(entry.Cards)
+		}
+	}
+	header := make([]string, nCards+1)
+	for i := 0; i < nCards; i++ {
+		header[i] = fmt.Sprintf("card%d", i+1)
+	}
+	header[nCards] = "word"
+	cw.Write(header)
+	for _, entry := range e.Entries {
+		record := make([]string, nCards+1)
+		for i, c := range entry.Cards {
+			record[i] = c.Name
+		}
+		record[nCards] = entry.Word
+		cw.Write(record)
+	}
+
+	if len(e.Symbols) > 0 {
+		cw.Write(nil)
+		cw.Write([]string{"value", "color", "symbol"})
+		for _, s := range e.Symbols {
+			cw.Write([]string{s.Value, s.Color, s.Symbol})
+		}
+	}
+
+	if e.Capital != "" {
+		cw.Write(nil)
+		cw.Write([]string{"capital"})
+		cw.Write([]string{e.Capital})
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,63 @@
+package cardware
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestExport_WriteCSV(t *testing.T) {
+	var e Export
+	deck := NewStandardFrenchDeck()
+	if err := e.Add(deck, []rune{'🂡', '🂲'}, "apple"); err != nil {
+		t.Fatalf("Export.Add() error = %v", err)
+	}
+	e.Symbols = []ExportSymbol{{Value: "A", Color: "B", Symbol: ","}}
+	e.Capital = "R"
+
+	want := "card1,card2,word\nA♠,2♡,apple\n\nvalue,color,symbol\nA,B,\",\"\n\ncapital\nR\n"
+	var buf bytes.Buffer
+	if err := e.WriteCSV(&buf); err != nil {
+		t.Fatalf("Export.WriteCSV() error = %v", err)
+	}
+	if got := buf.String(); got != want {
+		t.Errorf("Export.WriteCSV() = %q, want %q", got, want)
+	}
+}
+
+func TestExport_WriteJSON(t *testing.T) {
+	var e Export
+	ro := NewComposite(NewCoinBag(1), NewDiceBag([]int{6}), NewStandardFrenchDeck())
+	if err := e.Add(ro, []rune{1, 2, '🂡'}, "apple"); err != nil {
+		t.Fatalf("Export.Add() error = %v", err)
+	}
+	e.Capital = "R"
+
+	want := `{
+  "entries": [
+    {
+      "cards": [
+        {
+          "name": "T"
+        },
+        {
+          "name": "3"
+        },
+        {
+          "name": "A♠",
+          "rune": "🂡"
+        }
+      ],
+      "word": "apple"
+    }
+  ],
+  "capital": "R"
+}
+`
+	var buf bytes.Buffer
+	if err := e.WriteJSON(&buf); err != nil {
+		t.Fatalf("Export.WriteJSON() error = %v", err)
+	}
+	if got := buf.String(); got != want {
+		t.Errorf("Export.WriteJSON() = %s, want %s", got, want)
+	}
+}
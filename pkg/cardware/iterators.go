@@ -0,0 +1,164 @@
+package cardware
+
+import (
+	"gonum.org/v1/gonum/stat/combin"
+)
+
+// OutcomeIterator steps through the distinct outcomes of a fixed number of draws
+// from a RandomObject, in the order of Outcome and Index. An iterator holds its
+// own place in the enumeration and a copy of the state of the object it was
+// created from, so any number of iterators can step through the outcomes of
+// the same object at once, and shuffling the object does not disturb them.
+type OutcomeIterator interface {
+	// Next returns the next outcome, or nil once every outcome has been returned.
+	Next() []rune
+}
+
+// Seq returns the outcomes of k draws from ro as a function that calls yield
+// with each outcome in turn, stopping early if yield returns false. If k draws
+// cannot be made from ro, yield is never called.
+func Seq(ro RandomObject, k int) func(yield func([]rune) bool) {
+	return func(yield func([]rune) bool) {
+		it, err := ro.Outcomes(k)
+		if err != nil {
+			return
+		}
+		for o := it.Next(); o != nil; o = it.Next() {
+			if !yield(o) {
+				return
+			}
+		}
+	}
+}
+
+// deckIterator enumerates the outcomes of k draws from a snapshot of a deck.
+// Exactly one of its generators is used, depending on the draw mode.
+type deckIterator struct {
+	cards []Card
+	types []Card
+	mode  DrawMode
+	done  bool
+	pg    *combin.PermutationGenerator
+	mg    *multisetGenerator
+	hg    *handGenerator
+	cg    *combin.CartesianGenerator
+}
+
+// Outcomes implements RandomObject interface.
+func (d *Deck) Outcomes(k int) (OutcomeIterator, error) {
+	if err := checkDraws(k, d.MaxDraws()); err != nil {
+		return nil, err
+	}
+	md := len(d.cards)
+	cards := make([]Card, md)
+	copy(cards, d.cards)
+	types, counts := d.multiset()
+	it := &deckIterator{cards: cards, types: types, mode: d.mode}
+	switch {
+	case d.mode == Unordered:
+		it.hg = newHandGenerator(counts, k)
+	case d.mode == WithReplacement && k > 0:
+		lens := make([]int, k)
+		for i := range lens {
+			lens[i] = len(types)
+		}
+		it.cg = combin.NewCartesianGenerator(lens)
+	case d.HasDuplicates():
+		it.mg = newMultisetGenerator(counts, k)
+	default:
+		// the single outcome of no draws is enumerated here in every mode
+		it.pg = combin.NewPermutationGenerator(md, k)
+	}
+	return it, nil
+}
+
+// Next implements OutcomeIterator interface.
+func (it *deckIterator) Next() []rune {
+	if it.done {
+		return nil
+	}
+	var seq []int
+	var from []Card
+	switch {
+	case it.hg != nil:
+		if it.done = !it.hg.Next(); !it.done {
+			seq, from = it.hg.Hand(), it.types
+		}
+	case it.cg != nil:
+		if it.done = !it.cg.Next(); !it.done {
+			seq, from = it.cg.Product(nil), it.types
+		}
+	case it.mg != nil:
+		if it.done = !it.mg.Next(); !it.done {
+			seq, from = it.mg.Sequence(), it.types
+		}
+	default:
+		if it.done = !it.pg.Next(); !it.done {
+			seq, from = it.pg.Permutation(nil), it.cards
+		}
+	}
+	if it.done {
+		return nil
+	}
+	out := make([]rune, len(seq))
+	for i, x := range seq {
+		out[i] = rune(from[x])
+	}
+	if it.mode == Unordered {
+		SortHand(out)
+	}
+	return out
+}
+
+// diceIterator enumerates the outcomes of k rolls from a snapshot of a dice bag.
+type diceIterator struct {
+	bag  *DiceBag
+	k    int
+	done bool
+	cg   *combin.CartesianGenerator
+	rg   *rollGenerator
+}
+
+// Outcomes implements RandomObject interface.
+func (d *DiceBag) Outcomes(k int) (OutcomeIterator, error) {
+	if err := checkDraws(k, d.MaxDraws()); err != nil {
+		return nil, err
+	}
+	bag := d.clone()
+	it := &diceIterator{bag: bag, k: k}
+	switch {
+	case k == 0:
+		it.rg = newRollGenerator(bag, 0, nil)
+	case d.mode == Unordered:
+		weight, _ := bag.rollClass(k)
+		it.rg = newRollGenerator(bag, k, weight)
+	default:
+		it.cg = combin.NewCartesianGenerator(bag.dice[:k])
+	}
+	return it, nil
+}
+
+// Next implements OutcomeIterator interface.
+func (it *diceIterator) Next() []rune {
+	for !it.done {
+		if it.cg != nil {
+			if it.done = !it.cg.Next(); it.done {
+				break
+			}
+			out := make([]rune, it.k)
+			for i, x := range it.cg.Product(nil) {
+				out[i] = rune(it.bag.offset(i) + it.bag.face(i, x))
+			}
+			return out
+		}
+		if it.done = !it.rg.Next(); it.done {
+			break
+		}
+		out := it.rg.Roll()
+		// only rolls in the mapped class are enumerated
+		if it.k == 0 || it.bag.Weight(out).Cmp(it.rg.weight) == 0 {
+			return out
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package cardware
+
+import (
+	"math/big"
+	"math/rand"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func collect(it OutcomeIterator, err error) [][]rune {
+	out := make([][]rune, 0)
+	if err != nil {
+		return out
+	}
+	for o := it.Next(); o != nil; o = it.Next() {
+		out = append(out, o)
+	}
+	return out
+}
+
+func TestOutcomes(t *testing.T) {
+	tests := []struct {
+		name string
+		ro   RandomObject
+		k    int
+		want int
+	}{
+		{"deck-0", NewStandardFrenchDeck(), 0, 1},
+		{"deck-2", NewStandardFrenchDeck(), 2, 52 * 51},
+		{"dice-0", NewDiceBag([]int{6}), 0, 1},
+		{"dice-2", NewDiceBag([]int{6, 4}), 2, 24},
+		{"composite", NewComposite(NewCoinBag(2), NewStandardFrenchDeck()), 3, 4 * 52},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, err := tt.ro.Outcomes(tt.k)
+			if err != nil {
+				t.Fatalf("Outcomes() error = %v", err)
+			}
+			second, _ := tt.ro.Outcomes(tt.k)
+			// interleaved iterators do not disturb each other
+			for i := 0; i < tt.want; i++ {
+				a, b := first.Next(), second.Next()
+				if a == nil || !reflect.DeepEqual(a, b) {
+					t.Fatalf("Outcomes() #%d = %v and %v, want equal outcomes", i, a, b)
+				}
+				if index, err := tt.ro.Index(a); err != nil || index.Cmp(big.NewInt(int64(i))) != 0 {
+					t.Fatalf("Index(%v) = %v, %v, want %d", a, index, err, i)
+				}
+			}
+			if o := first.Next(); o != nil {
+				t.Errorf("Outcomes() returned %v after the last outcome", o)
+			}
+			if o := first.Next(); o != nil {
+				t.Errorf("Outcomes() restarted with %v", o)
+			}
+		})
+	}
+}
+
+func TestOutcomes_Concurrent(t *testing.T) {
+	deck := NewTarotDeMarseilleDeck()
+	want := collect(deck.Outcomes(2))
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := collect(deck.Outcomes(2)); !reflect.DeepEqual(got, want) {
+				t.Errorf("concurrent Outcomes() differ")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestOutcomes_Shuffle(t *testing.T) {
+	deck := NewStandardFrenchDeck()
+	it, err := deck.Outcomes(1)
+	if err != nil {
+		t.Fatalf("Outcomes() error = %v", err)
+	}
+	deck.Shuffle(rand.NewSource(1))
+	for i, c := range FrenchCards {
+		if o := it.Next(); len(o) != 1 || o[0] != c {
+			t.Fatalf("Outcomes() #%d after Shuffle() = %v, want %c", i, o, c)
+		}
+	}
+}
+
+func TestOutcomes_ShuffleComposite(t *testing.T) {
+	deck := customDeck(t, `{"name": "test", "suits": [{"name": "S"}], "ranks": ["A", "B", "C"]}`)
+	c := NewComposite(NewDiceBag([]int{2}), deck, NewDiceBag([]int{3}))
+	want := collect(c.Outcomes(5))
+	it, err := c.Outcomes(5)
+	if err != nil {
+		t.Fatalf("Outcomes() error = %v", err)
+	}
+	for i, w := range want {
+		if i == 1 {
+			c.Shuffle(rand.NewSource(1))
+		}
+		if o := it.Next(); !reflect.DeepEqual(o, w) {
+			t.Fatalf("Composite.Outcomes() #%d after Shuffle() = %v, want %v", i, o, w)
+		}
+	}
+	if o := it.Next(); o != nil {
+		t.Errorf("Composite.Outcomes() = %v, want nil", o)
+	}
+}
+
+func TestSeq(t *testing.T) {
+	n := 0
+	Seq(NewDiceBag([]int{6, 6}), 2)(func(o []rune) bool {
+		n++
+		return n < 10
+	})
+	if n != 10 {
+		t.Errorf("Seq() yielded %d outcomes after stopping at 10", n)
+	}
+}
@@ -0,0 +1,197 @@
+package cardware
+
+import (
+	"math/big"
+)
+
+// NewRepeatedDeck builds a deck of copies of d shuffled together, such as a
+// double deck of French cards. Every card appears copies times.
+func NewRepeatedDeck(d *Deck, copies int) *Deck {
+	cards := make([]Card, 0, len(d.cards)*copies)
+	for i := 0; i < copies; i++ {
+		cards = append(cards, d.cards...)
+	}
+	r := d.clone()
+	r.cards = cards
+	return r
+}
+
+// NewPinochleDeck builds a 48-card pinochle deck: two copies of the nine
+// through ace of each of the four French suits.
+func NewPinochleDeck() *Deck {
+	cards := make([]Card, 0, 24)
+	for _, c := range FrenchCards {
+		// ace, then nine through king
+		if rank := Card(c).Rank(); rank == Ace || rank >= Nine {
+			cards = append(cards, Card(c))
+		}
+	}
+	d := &Deck{
+		cards:  cards,
+		draws:  -1,
+		tr:     TranslateFrench,
+		parse:  ParseCard,
+		values: []string{"A", "9", "T", "J", "Q", "K"},
+		colors: runeStrings(FrenchColors),
+	}
+	return NewRepeatedDeck(d, 2)
+}
+
+// HasDuplicates reports whether any card appears in the deck more than once.
+//
+// Decks with duplicates count and enumerate only distinct sequences of cards.
+// Note that distinct sequences are not equally likely to be drawn when they
+// contain repeated cards: in a double deck, drawing the same card twice is
+// half as likely as drawing two different cards. Weight gives the relative
+// likelihood of any outcome, and NewEntropy accounts for it.
+func (d *Deck) HasDuplicates() bool {
+	seen := make(map[Card]bool, len(d.cards))
+	for _, c := range d.cards {
+		if seen[c] {
+			return true
+		}
+		seen[c] = true
+	}
+	return false
+}
+
+// Weight returns the number of ways the outcome can be drawn when identical
+// cards cannot be told apart. Every outcome of a deck without duplicates can be
+// drawn in only one way.
+func (d *Deck) Weight(outcome []rune) *big.Int {
+	w := big.NewInt(1)
+	if !d.HasDuplicates() {
+		return w
+	}
+	types, counts := d.multiset()
+	drawn := make(map[Card]int64, len(outcome))
+	for _, r := range outcome {
+		drawn[Card(r)]++
+	}
+	m := new(big.Int)
+	for i, t := range types {
+		c := drawn[t]
+		if c == 0 {
+			continue
+		}
+		n := int64(counts[i])
+		switch d.mode {
+		case WithReplacement:
+			w.Mul(w, m.Exp(big.NewInt(n), big.NewInt(c), nil))
+		case Unordered:
+			w.Mul(w, m.Binomial(n, c))
+		default:
+			w.Mul(w, m.MulRange(n-c+1, n))
+		}
+	}
+	return w
+}
+
+// uniform implements weigher interface.
+func (d *Deck) uniform() bool {
+	return !d.HasDuplicates()
+}
+
+// multiset returns the distinct cards of the deck, in order of first
+// appearance, and the number of times each appears.
+func (d *Deck) multiset() ([]Card, []int) {
+	index := make(map[Card]int, len(d.cards))
+	types := make([]Card, 0, len(d.cards))
+	counts := make([]int, 0, len(d.cards))
+	for _, c := range d.cards {
+		i, ok := index[c]
+		if !ok {
+			i = len(types)
+			index[c] = i
+			types = append(types, c)
+			counts = append(counts, 0)
+		}
+		counts[i]++
+	}
+	return types, counts
+}
+
+// countMultisetPermutations counts the distinct sequences of k elements drawn
+// without replacement from a multiset with the given multiplicities.
+func countMultisetPermutations(counts []int, k int) *big.Int {
+	// ways[j] is the number of distinct sequences of length j using the
+	// multiplicities considered so far.
+	ways := make([]*big.Int, k+1)
+	ways[0] = big.NewInt(1)
+	for j := 1; j <= k; j++ {
+		ways[j] = big.NewInt(0)
+	}
+	binom := new(big.Int)
+	term := new(big.Int)
+	for _, m := range counts {
+		next := make([]*big.Int, k+1)
+		for j := 0; j <= k; j++ {
+			next[j] = big.NewInt(0)
+			// place t copies of this element among the j positions
+			for t := 0; t <= m && t <= j; t++ {
+				binom.Binomial(int64(j), int64(t))
+				term.Mul(ways[j-t], binom)
+				next[j].Add(next[j], term)
+			}
+		}
+		ways = next
+	}
+	return ways[k]
+}
+
+// multisetGenerator enumerates the distinct sequences of k elements drawn
+// without replacement from a multiset, in lexicographic order of element index.
+type multisetGenerator struct {
+	avail []int
+	seq   []int
+	begun bool
+}
+
+func newMultisetGenerator(counts []int, k int) *multisetGenerator {
+	avail := make([]int, len(counts))
+	copy(avail, counts)
+	return &multisetGenerator{avail: avail, seq: make([]int, k)}
+}
+
+// fill places the smallest available elements in positions from onward.
+func (g *multisetGenerator) fill(from int) bool {
+	t := 0
+	for i := from; i < len(g.seq); i++ {
+		for t < len(g.avail) && g.avail[t] == 0 {
+			t++
+		}
+		if t == len(g.avail) {
+			return false
+		}
+		g.seq[i] = t
+		g.avail[t]--
+	}
+	return true
+}
+
+// Next advances to the next sequence, returning false when all sequences have
+// been generated.
+func (g *multisetGenerator) Next() bool {
+	if !g.begun {
+		g.begun = true
+		return g.fill(0)
+	}
+	for i := len(g.seq) - 1; i >= 0; i-- {
+		g.avail[g.seq[i]]++
+		for t := g.seq[i] + 1; t < len(g.avail); t++ {
+			if g.avail[t] > 0 {
+				g.seq[i] = t
+				g.avail[t]--
+				return g.fill(i + 1)
+			}
+		}
+	}
+	return false
+}
+
+// Sequence returns the current sequence of element indices.
+func (g *multisetGenerator) Sequence() []int {
+	out := make([]int, len(g.seq))
+	copy(out, g.seq)
+	return out
+}
@@ -0,0 +1,227 @@
+package cardware
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DeckOptions select the deck to build with NewDeckFromOptions.
+type DeckOptions struct {
+	// Type is the name of a registered deck. It is ignored if File is given.
+	Type string
+	// File is the path of a JSON custom deck definition to load, if any.
+	File string
+	// Jokers is the number of jokers (2 or 3) to add to a French deck, or zero.
+	Jokers int
+	// Suits are the suits to keep in a stripped French or tarot deck, if any.
+	Suits string
+	// Ranks are the values to keep in a stripped French or tarot deck, if any.
+	Ranks string
+	// Trumps are the trumps to keep in a stripped French or tarot deck, if any.
+	Trumps string
+	// Copies is the number of identical decks shuffled together. Zero is taken
+	// as one.
+	Copies int
+}
+
+// NewDeckFromOptions builds the deck loaded from opts.File, if given, or the
+// registered deck named by opts.Type. Jokers may only be added to French decks.
+// French and tarot decks are stripped to the given suits, values, and trumps,
+// if any. The deck is then repeated the given number of copies.
+func NewDeckFromOptions(opts DeckOptions) (*Deck, error) {
+	if opts.Copies < 0 {
+		return nil, fmt.Errorf("number of decks %d not valid", opts.Copies)
+	}
+	deck, err := newDeck(opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Copies > 1 {
+		deck = NewRepeatedDeck(deck, opts.Copies)
+	}
+	return deck, nil
+}
+
+// newDeck builds a single copy of the deck selected by opts.
+func newDeck(opts DeckOptions) (*Deck, error) {
+	if opts.Jokers != 0 && (opts.File != "" || !strings.EqualFold(opts.Type, "french")) {
+		return nil, fmt.Errorf("jokers can only be added to French decks")
+	}
+	stripped := opts.Suits != "" || opts.Ranks != "" || opts.Trumps != ""
+	if opts.File != "" && stripped {
+		return nil, fmt.Errorf("custom decks cannot be stripped")
+	}
+	if opts.Jokers != 0 && stripped {
+		return nil, fmt.Errorf("jokers cannot be added to stripped decks")
+	}
+	if opts.File != "" {
+		file, err := os.Open(opts.File)
+		if err != nil {
+			return nil, fmt.Errorf("deck file '%s' : %v", opts.File, err)
+		}
+		defer file.Close()
+		deck, err := LoadDeck(file)
+		if err != nil {
+			return nil, fmt.Errorf("deck file '%s' : %v", opts.File, err)
+		}
+		return deck, nil
+	}
+	if stripped {
+		return stripDeck(opts)
+	}
+	switch opts.Jokers {
+	case 0:
+		return NewRegisteredDeck(opts.Type)
+	case 2:
+		return NewFrenchDeckWithJokers(false), nil
+	case 3:
+		return NewFrenchDeckWithJokers(true), nil
+	}
+	return nil, fmt.Errorf("number of jokers %d not valid", opts.Jokers)
+}
+
+// stripDeck builds a French or tarot deck with only the given suits, values, and
+// trumps.
+func stripDeck(opts DeckOptions) (*Deck, error) {
+	var b *DeckBuilder
+	switch strings.ToLower(opts.Type) {
+	case "french":
+		b = NewFrenchDeckBuilder()
+	case "tarot":
+		b = NewTarotDeckBuilder()
+	default:
+		return nil, fmt.Errorf("only French and tarot decks can be stripped")
+	}
+	if opts.Suits != "" {
+		if err := b.ParseSuits(opts.Suits); err != nil {
+			return nil, fmt.Errorf("suits \"%s\" : %v", opts.Suits, err)
+		}
+	}
+	if opts.Ranks != "" {
+		if err := b.ParseRanks(opts.Ranks); err != nil {
+			return nil, fmt.Errorf("values \"%s\" : %v", opts.Ranks, err)
+		}
+	}
+	if opts.Trumps != "" {
+		if err := b.ParseTrumps(opts.Trumps); err != nil {
+			return nil, fmt.Errorf("trumps \"%s\" : %v", opts.Trumps, err)
+		}
+	}
+	return b.Build()
+}
+
+// DiceOptions select the coins and dice to roll with NewDiceFromOptions.
+type DiceOptions struct {
+	// Coins is the number of coins, flipped before the dice are rolled.
+	Coins int
+	// Dice are the dice to roll, in the notation read by ParseDice, if any.
+	Dice string
+	// Unordered rolls identical dice and coins together, so their order does
+	// not matter.
+	Unordered bool
+}
+
+// NewDiceFromOptions builds a bag of the coins followed by the dice given in
+// opts. Faces of dice without labels are labeled with their numbers.
+func NewDiceFromOptions(opts DiceOptions) (*DiceBag, error) {
+	if opts.Coins < 0 {
+		return nil, fmt.Errorf("number of coins %d not valid", opts.Coins)
+	}
+	faces := make([][]string, 0, opts.Coins)
+	for i := 0; i < opts.Coins; i++ {
+		faces = append(faces, CoinFaces)
+	}
+	if opts.Dice != "" {
+		dice, err := ParseDice(opts.Dice)
+		if err != nil {
+			return nil, err
+		}
+		faces = append(faces, dice...)
+	}
+	bag := NewLabeledDiceBag(faces)
+	if opts.Unordered {
+		bag.SetDrawMode(Unordered)
+	}
+	return bag, nil
+}
+
+var diceNotation = regexp.MustCompile(`^(\d+)?[dD](?:(\d+)|([a-zA-Z][\w-]*)|\{([^{}\s\[\]]+)\})$`)
+
+// ParseDice reads dice given in [N]dX+[N]dX+... notation, where X is a number
+// of faces, the name of a registered die, or {a,b,...} for labeled faces. It
+// returns the labels of the faces of each die, numbering the faces of dice
+// given by their number of faces from one.
+func ParseDice(s string) ([][]string, error) {
+	out := make([][]string, 0)
+	for _, val := range splitDice(s) {
+		m := diceNotation.FindStringSubmatch(val)
+		if m == nil {
+			return nil, fmt.Errorf("invalid dice identifier '%s'", val)
+		}
+		n := 1
+		var err error
+		if m[1] != "" {
+			n, err = strconv.Atoi(m[1])
+			if err != nil {
+				return nil, err
+			}
+		}
+		var labels []string
+		switch {
+		case m[3] != "":
+			labels, err = RegisteredDieFaces(m[3])
+			if err != nil {
+				return nil, fmt.Errorf("invalid dice identifier '%s': %v", val, err)
+			}
+		case m[4] != "":
+			labels = strings.Split(m[4], ",")
+			seen := make(map[string]bool)
+			for _, l := range labels {
+				if l == "" || seen[l] {
+					return nil, fmt.Errorf("invalid dice identifier '%s': labels must be distinct and not empty", val)
+				}
+				seen[l] = true
+			}
+		default:
+			faces, err := strconv.Atoi(m[2])
+			if err != nil {
+				return nil, err
+			}
+			if faces < 1 {
+				return nil, fmt.Errorf("invalid dice identifier '%s': dice must have at least one face", val)
+			}
+			labels = make([]string, faces)
+			for f := range labels {
+				labels[f] = strconv.Itoa(f + 1)
+			}
+		}
+		for i := 0; i < n; i++ {
+			out = append(out, labels)
+		}
+	}
+	return out, nil
+}
+
+// splitDice splits dice notation on the plus signs that are not part of a die's labels.
+func splitDice(s string) []string {
+	out := make([]string, 0)
+	depth := 0
+	start := 0
+	for i, c := range s {
+		switch c {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case '+':
+			if depth == 0 {
+				out = append(out, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(out, s[start:])
+}
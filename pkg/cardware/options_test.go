@@ -0,0 +1,158 @@
+package cardware
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewDeckFromOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    DeckOptions
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "registered",
+			opts: DeckOptions{Type: "french"},
+			want: 52,
+		},
+		{
+			name: "jokers",
+			opts: DeckOptions{Type: "French", Jokers: 3},
+			want: 55,
+		},
+		{
+			name: "stripped",
+			opts: DeckOptions{Type: "tarot", Suits: "SC", Ranks: "A,K"},
+			want: 26, // four suited cards and the 22 trumps
+		},
+		{
+			name: "trumps",
+			opts: DeckOptions{Type: "tarot", Trumps: "0, 11-21"},
+			want: 68, // the 56 suited cards and 12 trumps
+		},
+		{
+			name: "no-trumps",
+			opts: DeckOptions{Type: "tarot", Suits: "S", Trumps: "none"},
+			want: 14,
+		},
+		{
+			name: "french-trumps",
+			opts: DeckOptions{Type: "french", Trumps: "21"},
+			want: 53,
+		},
+		{
+			name: "copies",
+			opts: DeckOptions{Type: "euchre", Copies: 2},
+			want: 48,
+		},
+		{
+			name: "file",
+			opts: DeckOptions{File: "../../sample-deck.json"},
+			want: 32,
+		},
+		{
+			name:    "jokers-not-french",
+			opts:    DeckOptions{Type: "tarot", Jokers: 2},
+			wantErr: true,
+		},
+		{
+			name:    "jokers-not-valid",
+			opts:    DeckOptions{Type: "french", Jokers: 1},
+			wantErr: true,
+		},
+		{
+			name:    "jokers-stripped",
+			opts:    DeckOptions{Type: "french", Jokers: 2, Ranks: "A"},
+			wantErr: true,
+		},
+		{
+			name:    "file-stripped",
+			opts:    DeckOptions{File: "../../sample-deck.json", Suits: "S"},
+			wantErr: true,
+		},
+		{
+			name:    "trumps-not-valid",
+			opts:    DeckOptions{Type: "tarot", Trumps: "20-22"},
+			wantErr: true,
+		},
+		{
+			name:    "copies-not-valid",
+			opts:    DeckOptions{Type: "french", Copies: -1},
+			wantErr: true,
+		},
+		{
+			name:    "not-strippable",
+			opts:    DeckOptions{Type: "pinochle", Suits: "S"},
+			wantErr: true,
+		},
+		{
+			name:    "missing-file",
+			opts:    DeckOptions{File: "no-such-deck.json"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewDeckFromOptions(tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewDeckFromOptions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := d.MaxDraws(); got != tt.want {
+				t.Errorf("Deck.MaxDraws() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDice(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    [][]string
+		wantErr bool
+	}{
+		{"numbered", "2d3", [][]string{{"1", "2", "3"}, {"1", "2", "3"}}, false},
+		{"named", "dF+d2", [][]string{FudgeFaces, {"1", "2"}}, false},
+		{"labeled", "1d{a,+,b}", [][]string{{"a", "+", "b"}}, false},
+		{"no-faces", "d0", nil, true},
+		{"repeated-label", "d{a,a}", nil, true},
+		{"unknown", "dX", nil, true},
+		{"malformed", "6", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDice(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDice(%s) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseDice(%s) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDiceFromOptions(t *testing.T) {
+	bag, err := NewDiceFromOptions(DiceOptions{Coins: 2, Dice: "d6", Unordered: true})
+	if err != nil {
+		t.Fatalf("NewDiceFromOptions() error = %v", err)
+	}
+	if got := bag.MaxDraws(); got != 3 {
+		t.Errorf("DiceBag.MaxDraws() = %v, want 3", got)
+	}
+	if got := bag.DrawMode(); got != Unordered {
+		t.Errorf("DiceBag.DrawMode() = %v, want %v", got, Unordered)
+	}
+	if got, err := TranslateOutcome(bag, []rune{1, 2, 9}); err != nil || !reflect.DeepEqual(got, []string{"[T]", "[H]", "[6]"}) {
+		t.Errorf("TranslateOutcome() = %v, %v, want [[T] [H] [6]]", got, err)
+	}
+	if _, err := NewDiceFromOptions(DiceOptions{Coins: -1}); err == nil {
+		t.Errorf("NewDiceFromOptions() with negative coins succeeded")
+	}
+}
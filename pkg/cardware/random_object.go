@@ -7,10 +7,25 @@ import (
 
 // RandomObject is an interface to a thing that can draw random elements from a fixed set
 // (like a deck of cards or a single die).
+//
+// Outcomes returns an iterator over the distinct outcomes of k draws. Outcome
+// returns the outcome of k draws at the given index in the order that Outcomes
+// enumerates them, and Index returns the index of an outcome.
+//
+// Methods that take a number of draws k return an error wrapping
+// ErrTooManyDraws if k is greater than MaxDraws and ErrNegativeDraws if k is
+// negative. Indices, outcomes, and runes that the object cannot produce are
+// reported with errors wrapping ErrOutOfBounds.
+//
+// The runes of an outcome are only meaningful to the object that drew them.
+// Use Tag to find the source of each rune of an outcome from a Composite.
 type RandomObject interface {
 	MaxDraws() int
-	CountDistinctOutcomes(k int) *big.Int
-	NextOutcome(k int) []rune
+	CountDistinctOutcomes(k int) (*big.Int, error)
+	Outcomes(k int) (OutcomeIterator, error)
+	RandomOutcome(k int, src rand.Source) ([]rune, error)
+	Outcome(k int, index *big.Int) ([]rune, error)
+	Index(outcome []rune) (*big.Int, error)
 	Translate(r rune) (string, error)
 }
 
@@ -0,0 +1,372 @@
+package cardware
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// Outcome and Index convert between outcomes and their positions in the order
+// Outcomes enumerates them, without stepping through the outcomes before.
+// Ordered draws from a deck follow the order of gonum's PermutationGenerator:
+// outcomes are grouped by the lexicographic index of the combination of cards
+// drawn, then by the Lehmer code of the order in which they were drawn. All
+// other outcomes are ranked lexicographically or, for dice, in mixed radix.
+
+// factorial returns n!.
+func factorial(n int) *big.Int {
+	return new(big.Int).MulRange(1, int64(n))
+}
+
+// combinationRank returns the lexicographic index of the sorted combination
+// comb of k elements of [0, n).
+func combinationRank(comb []int, n int) *big.Int {
+	k := len(comb)
+	idx := big.NewInt(0)
+	c := new(big.Int)
+	v := 0
+	for i, x := range comb {
+		for ; v < x; v++ {
+			idx.Add(idx, c.Binomial(int64(n-v-1), int64(k-i-1)))
+		}
+		v = x + 1
+	}
+	return idx
+}
+
+// combinationUnrank returns the combination of k elements of [0, n) with the
+// given lexicographic index.
+func combinationUnrank(index *big.Int, n, k int) []int {
+	idx := new(big.Int).Set(index)
+	comb := make([]int, k)
+	c := new(big.Int)
+	v := 0
+	for i := range comb {
+		for ; ; v++ {
+			c.Binomial(int64(n-v-1), int64(k-i-1))
+			if idx.Cmp(c) < 0 {
+				break
+			}
+			idx.Sub(idx, c)
+		}
+		comb[i] = v
+		v++
+	}
+	return comb
+}
+
+// permutationRank returns the index of a permutation of k distinct elements of
+// [0, n) in the order of gonum's PermutationGenerator.
+func permutationRank(perm []int, n int) *big.Int {
+	k := len(perm)
+	sorted := make([]int, k)
+	copy(sorted, perm)
+	sort.Ints(sorted)
+	idx := combinationRank(sorted, n)
+	idx.Mul(idx, factorial(k))
+	// Lehmer code of the order of the elements
+	f := new(big.Int)
+	for i, u := range perm {
+		less := 0
+		for _, v := range perm[i+1:] {
+			if v < u {
+				less++
+			}
+		}
+		f.Mul(factorial(k-i-1), big.NewInt(int64(less)))
+		idx.Add(idx, f)
+	}
+	return idx
+}
+
+// permutationUnrank returns the permutation of k distinct elements of [0, n)
+// with the given index in the order of gonum's PermutationGenerator.
+func permutationUnrank(index *big.Int, n, k int) []int {
+	combIdx, permIdx := new(big.Int).QuoRem(index, factorial(k), new(big.Int))
+	comb := combinationUnrank(combIdx, n, k)
+	perm := make([]int, k)
+	r := new(big.Int)
+	for i := range perm {
+		f := factorial(k - i - 1)
+		permIdx.QuoRem(permIdx, f, r)
+		j := int(permIdx.Int64())
+		perm[i] = comb[j]
+		comb = append(comb[:j], comb[j+1:]...)
+		permIdx.Set(r)
+	}
+	return perm
+}
+
+// multisetPermutationRank returns the lexicographic index of a sequence drawn
+// from a multiset with the given multiplicities.
+func multisetPermutationRank(seq []int, counts []int) *big.Int {
+	avail := make([]int, len(counts))
+	copy(avail, counts)
+	idx := big.NewInt(0)
+	for i, x := range seq {
+		for t := 0; t < x; t++ {
+			if avail[t] == 0 {
+				continue
+			}
+			avail[t]--
+			idx.Add(idx, countMultisetPermutations(avail, len(seq)-i-1))
+			avail[t]++
+		}
+		avail[x]--
+	}
+	return idx
+}
+
+// multisetPermutationUnrank returns the sequence of k elements drawn from a
+// multiset with the given multiplicities that has the given lexicographic index.
+func multisetPermutationUnrank(index *big.Int, counts []int, k int) []int {
+	avail := make([]int, len(counts))
+	copy(avail, counts)
+	idx := new(big.Int).Set(index)
+	seq := make([]int, k)
+	for i := range seq {
+		for t := range avail {
+			if avail[t] == 0 {
+				continue
+			}
+			avail[t]--
+			n := countMultisetPermutations(avail, k-i-1)
+			if idx.Cmp(n) < 0 {
+				seq[i] = t
+				break
+			}
+			idx.Sub(idx, n)
+			avail[t]++
+		}
+	}
+	return seq
+}
+
+// handRank returns the lexicographic index of a nondecreasing hand drawn from a
+// multiset with the given multiplicities.
+func handRank(hand []int, counts []int) *big.Int {
+	avail := make([]int, len(counts))
+	copy(avail, counts)
+	idx := big.NewInt(0)
+	from := 0
+	for i, x := range hand {
+		for t := from; t < x; t++ {
+			if avail[t] == 0 {
+				continue
+			}
+			avail[t]--
+			idx.Add(idx, countMultisetCombinations(avail[t:], len(hand)-i-1))
+			avail[t]++
+		}
+		avail[x]--
+		from = x
+	}
+	return idx
+}
+
+// handUnrank returns the nondecreasing hand of k elements drawn from a multiset
+// with the given multiplicities that has the given lexicographic index.
+func handUnrank(index *big.Int, counts []int, k int) []int {
+	avail := make([]int, len(counts))
+	copy(avail, counts)
+	idx := new(big.Int).Set(index)
+	hand := make([]int, k)
+	from := 0
+	for i := range hand {
+		for t := from; t < len(avail); t++ {
+			if avail[t] == 0 {
+				continue
+			}
+			avail[t]--
+			n := countMultisetCombinations(avail[t:], k-i-1)
+			if idx.Cmp(n) < 0 {
+				hand[i] = t
+				from = t
+				break
+			}
+			idx.Sub(idx, n)
+			avail[t]++
+		}
+	}
+	return hand
+}
+
+// mixedRadixRank returns the row-major index of digits in the given radices.
+func mixedRadixRank(digits []int, radices []int) *big.Int {
+	idx := big.NewInt(0)
+	for i, d := range digits {
+		idx.Mul(idx, big.NewInt(int64(radices[i])))
+		idx.Add(idx, big.NewInt(int64(d)))
+	}
+	return idx
+}
+
+// mixedRadixUnrank returns the digits of the row-major index in the given radices.
+func mixedRadixUnrank(index *big.Int, radices []int) []int {
+	idx := new(big.Int).Set(index)
+	digits := make([]int, len(radices))
+	r := new(big.Int)
+	for i := len(radices) - 1; i >= 0; i-- {
+		idx.QuoRem(idx, big.NewInt(int64(radices[i])), r)
+		digits[i] = int(r.Int64())
+	}
+	return digits
+}
+
+// Outcome implements RandomObject interface.
+func (d *Deck) Outcome(k int, index *big.Int) ([]rune, error) {
+	n, err := d.CountDistinctOutcomes(k)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkIndex(index, n); err != nil {
+		return nil, err
+	}
+	types, counts := d.multiset()
+	var seq []int
+	switch {
+	case k == 0:
+		seq = []int{}
+	case d.mode == Unordered && d.HasDuplicates():
+		seq = handUnrank(index, counts, k)
+	case d.mode == Unordered:
+		seq = combinationUnrank(index, len(types), k)
+	case d.mode == WithReplacement:
+		radices := make([]int, k)
+		for i := range radices {
+			radices[i] = len(types)
+		}
+		seq = mixedRadixUnrank(index, radices)
+	case d.HasDuplicates():
+		seq = multisetPermutationUnrank(index, counts, k)
+	default:
+		seq = permutationUnrank(index, len(types), k)
+	}
+	out := make([]rune, k)
+	for i, x := range seq {
+		out[i] = rune(types[x])
+	}
+	if d.mode == Unordered {
+		SortHand(out)
+	}
+	return out, nil
+}
+
+// Index implements RandomObject interface. It returns an error if the outcome
+// cannot be drawn from the deck.
+func (d *Deck) Index(outcome []rune) (*big.Int, error) {
+	k := len(outcome)
+	if k > d.MaxDraws() {
+		return nil, fmt.Errorf("cannot draw %d cards from a %d-card deck: %w", k, d.MaxDraws(), ErrTooManyDraws)
+	}
+	types, counts := d.multiset()
+	index := make(map[rune]int, len(types))
+	for i, t := range types {
+		index[rune(t)] = i
+	}
+	seq := make([]int, k)
+	used := make([]int, len(types))
+	for i, r := range outcome {
+		t, ok := index[r]
+		if !ok {
+			return nil, fmt.Errorf("card '%c' is not in the deck: %w", r, ErrOutOfBounds)
+		}
+		used[t]++
+		if d.mode != WithReplacement && used[t] > counts[t] {
+			return nil, fmt.Errorf("card '%c' is drawn more times than it appears in the deck", r)
+		}
+		seq[i] = t
+	}
+	switch {
+	case k == 0:
+		return big.NewInt(0), nil
+	case d.mode == Unordered:
+		sort.Ints(seq)
+		if d.HasDuplicates() {
+			return handRank(seq, counts), nil
+		}
+		return combinationRank(seq, len(types)), nil
+	case d.mode == WithReplacement:
+		radices := make([]int, k)
+		for i := range radices {
+			radices[i] = len(types)
+		}
+		return mixedRadixRank(seq, radices), nil
+	case d.HasDuplicates():
+		return multisetPermutationRank(seq, counts), nil
+	}
+	return permutationRank(seq, len(types)), nil
+}
+
+// Outcome implements RandomObject interface.
+func (d *DiceBag) Outcome(k int, index *big.Int) ([]rune, error) {
+	n, err := d.CountDistinctOutcomes(k)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkIndex(index, n); err != nil {
+		return nil, err
+	}
+	if d.mode == Unordered && k > 0 {
+		// unordered rolls are few enough to count through
+		weight, _ := d.rollClass(k)
+		rg := newRollGenerator(d, k, weight)
+		n := new(big.Int).Set(index)
+		for rg.Next() {
+			out := rg.Roll()
+			if d.Weight(out).Cmp(weight) != 0 {
+				continue
+			}
+			if n.Sign() == 0 {
+				return out, nil
+			}
+			n.Sub(n, big.NewInt(1))
+		}
+	}
+	out := make([]rune, k)
+	for i, x := range mixedRadixUnrank(index, d.dice[:k]) {
+		out[i] = rune(d.offset(i) + d.face(i, x))
+	}
+	return out, nil
+}
+
+// Index implements RandomObject interface. It returns an error if the outcome
+// cannot be rolled with the dice in the bag.
+func (d *DiceBag) Index(outcome []rune) (*big.Int, error) {
+	k := len(outcome)
+	if k > d.MaxDraws() {
+		return nil, fmt.Errorf("cannot roll %d dice from a bag of %d: %w", k, d.MaxDraws(), ErrTooManyDraws)
+	}
+	faces := d.faces(outcome)
+	for i, f := range faces {
+		if f < 0 || f >= d.dice[i] {
+			return nil, fmt.Errorf("face '%d' of die %d is %w", outcome[i], i+1, ErrOutOfBounds)
+		}
+	}
+	if d.mode == Unordered && k > 0 {
+		weight, _ := d.rollClass(k)
+		sorted := make([]rune, k)
+		copy(sorted, outcome)
+		d.sortRoll(sorted)
+		if d.Weight(sorted).Cmp(weight) != 0 {
+			return nil, fmt.Errorf("roll %v is not mapped and must be rerolled", d.faces(sorted))
+		}
+		rg := newRollGenerator(d, k, weight)
+		n := big.NewInt(0)
+		for rg.Next() {
+			out := rg.Roll()
+			if d.Weight(out).Cmp(weight) != 0 {
+				continue
+			}
+			if string(out) == string(sorted) {
+				break
+			}
+			n.Add(n, big.NewInt(1))
+		}
+		return n, nil
+	}
+	for i, f := range faces {
+		faces[i] = d.position(i, f)
+	}
+	return mixedRadixRank(faces, d.dice[:k]), nil
+}
@@ -0,0 +1,118 @@
+package cardware
+
+import (
+	"math/big"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func withMode(d *Deck, m DrawMode) *Deck {
+	d.SetDrawMode(m)
+	return d
+}
+
+func unorderedDice(d *DiceBag) *DiceBag {
+	d.SetDrawMode(Unordered)
+	return d
+}
+
+func TestOutcome_Index(t *testing.T) {
+	small := func() *Deck { return &Deck{cards: []Card{'a', 'b', 'c', 'd', 'e'}, tr: TranslateFrench} }
+	tests := []struct {
+		name string
+		ro   RandomObject
+		k    int
+	}{
+		{"small-0", small(), 0},
+		{"small-3", small(), 3},
+		{"small-5", small(), 5},
+		{"standard-2", NewStandardFrenchDeck(), 2},
+		{"hand-3", withMode(small(), Unordered), 3},
+		{"standard-hand-3", withMode(NewStandardFrenchDeck(), Unordered), 3},
+		{"replace-3", withMode(small(), WithReplacement), 3},
+		{"pinochle-2", NewPinochleDeck(), 2},
+		{"pinochle-hand-3", withMode(NewPinochleDeck(), Unordered), 3},
+		{"pinochle-replace-2", withMode(NewPinochleDeck(), WithReplacement), 2},
+		{"dice", NewDiceBag([]int{4, 6, 2}), 3},
+		{"unordered-dice", unorderedDice(NewDiceBag([]int{6, 4, 6, 6})), 4},
+		{"composite", NewComposite(NewCoinBag(1), small(), NewDiceBag([]int{3})), 5},
+		{"combined", NewCombinedFrom(NewDiceBag([]int{6}), NewStandardFrenchDeck()), 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			it, err := tt.ro.Outcomes(tt.k)
+			if err != nil {
+				t.Fatalf("Outcomes() error = %v", err)
+			}
+			i := int64(0)
+			for o := it.Next(); o != nil; o = it.Next() {
+				index := big.NewInt(i)
+				if got, err := tt.ro.Outcome(tt.k, index); err != nil || !reflect.DeepEqual(got, o) {
+					t.Fatalf("Outcome(%d, %d) = %v, want %v", tt.k, i, got, o)
+				}
+				got, err := tt.ro.Index(o)
+				if err != nil {
+					t.Fatalf("Index(%v) error = %v", o, err)
+				}
+				if got.Cmp(index) != 0 {
+					t.Fatalf("Index(%v) = %v, want %d", o, got, i)
+				}
+				i++
+			}
+			if n, err := tt.ro.CountDistinctOutcomes(tt.k); err != nil || n.Cmp(big.NewInt(i)) != 0 {
+				t.Errorf("enumerated %d outcomes, want %v", i, n)
+			}
+		})
+	}
+}
+
+func TestOutcome_IndexLarge(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tests := []struct {
+		d *Deck
+		k int
+	}{
+		{NewTarotDeMarseilleDeck(), 30},
+		{withMode(NewTarotDeMarseilleDeck(), Unordered), 30},
+		{NewRepeatedDeck(NewTarotDeMarseilleDeck(), 2), 6},
+	}
+	for _, tt := range tests {
+		n, err := tt.d.CountDistinctOutcomes(tt.k)
+		if err != nil {
+			t.Fatalf("CountDistinctOutcomes(%d) error = %v", tt.k, err)
+		}
+		for i := 0; i < 10; i++ {
+			index := new(big.Int).Rand(rng, n)
+			o, err := tt.d.Outcome(tt.k, index)
+			if err != nil {
+				t.Fatalf("Outcome(%d, %v) error = %v", tt.k, index, err)
+			}
+			got, err := tt.d.Index(o)
+			if err != nil || got.Cmp(index) != 0 {
+				t.Errorf("Index(Outcome(%d, %v)) = %v, %v", tt.k, index, got, err)
+			}
+		}
+	}
+}
+
+func TestIndex_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		ro      RandomObject
+		outcome []rune
+	}{
+		{"not-in-deck", NewStandardFrenchDeck(), []rune{TheFool}},
+		{"repeated", NewStandardFrenchDeck(), []rune{AceOfSpades, AceOfSpades}},
+		{"too-many", NewPinochleDeck(), []rune{AceOfSpades, AceOfSpades, AceOfSpades}},
+		{"bad-face", NewDiceBag([]int{6, 6}), []rune{0, 12}},
+		{"rerolled", unorderedDice(NewDiceBag([]int{6, 6})), []rune{0, 6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, err := tt.ro.Index(tt.outcome); err == nil {
+				t.Errorf("Index(%v) = %v, want error", tt.outcome, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package cardware
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Device describes a registered deck or die.
+type Device struct {
+	// Name selects the device. Names are not case sensitive.
+	Name string
+	// Description is a short, human-readable description of the device.
+	Description string
+}
+
+type deckEntry struct {
+	Device
+	new func() *Deck
+}
+
+type dieEntry struct {
+	Device
+	faces []string
+}
+
+var (
+	registryMu sync.RWMutex
+	decks      = make(map[string]deckEntry)
+	dice       = make(map[string]dieEntry)
+)
+
+func init() {
+	RegisterDeck("french", "standard 4-suited, 13-ranked deck", NewStandardFrenchDeck)
+	RegisterDeck("tarot", "Tarot de Marseille: 4-suited, 14-ranked deck with 22 trumps", NewTarotDeMarseilleDeck)
+	RegisterDeck("pinochle", "doubled 4-suited, 6-ranked deck", NewPinochleDeck)
+	RegisterDeck("piquet", "32-card piquet or skat deck: 7 through K and A of each French suit", NewPiquetDeck)
+	RegisterDeck("euchre", "24-card euchre deck: 9 through K and A of each French suit", NewEuchreDeck)
+	RegisterDeck("french-knights", "56-card French deck with a knight (N) between the jack and queen", NewFrenchDeckWithKnights)
+	RegisterDeck("tarot-major", "the 22 trumps of a Tarot de Marseille deck", NewTarotMajorArcanaDeck)
+	RegisterDeck("tarot-minor", "the 56 suited cards of a Tarot de Marseille deck", NewTarotMinorArcanaDeck)
+	RegisterDeck("spanish", "40-card Spanish deck: 1 through 7, sota, caballo, and rey of oros, copas, espadas, and bastos", NewSpanishDeck)
+	RegisterDeck("spanish-48", "48-card Spanish deck: 1 through 9, sota, caballo, and rey of each Spanish suit", NewSpanishDeck48)
+	RegisterDeck("italian", "40-card Italian deck: asso, 2 through 7, fante, cavallo, and re of denari, coppe, spade, and bastoni", NewItalianDeck)
+	RegisterDeck("german", "32-card German deck: 7 through 10, Unter, Ober, König, and Daus of Herz, Schellen, Laub, and Eichel", NewGermanDeck)
+	RegisterDeck("german-36", "36-card German deck: 6 through 10, Unter, Ober, König, and Daus of each German suit", NewGermanDeck36)
+	RegisterDeck("swiss", "36-card Swiss Jass deck: 6 through 9, Banner, Under, Ober, König, and Ass of Rosen, Schellen, Schilten, and Eicheln", NewSwissJassDeck)
+	RegisterDie("F", "Fudge die (-, 0, and + each on two faces)", FudgeFaces)
+	RegisterDie("coin", "coin (heads and tails)", CoinFaces)
+}
+
+// RegisterDeck makes a deck available by name. Every call to NewRegisteredDeck
+// with the name calls newDeck to build a new deck. Packages that define their
+// own decks can register them from an init function, so that any program that
+// selects decks by name can use them. RegisterDeck panics if newDeck is nil or
+// a deck is already registered with the name.
+func RegisterDeck(name, description string, newDeck func() *Deck) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if newDeck == nil {
+		panic("cardware: RegisterDeck constructor is nil")
+	}
+	key := strings.ToLower(name)
+	if _, dup := decks[key]; dup {
+		panic("cardware: RegisterDeck called twice for deck " + name)
+	}
+	decks[key] = deckEntry{Device{name, description}, newDeck}
+}
+
+// RegisterDie makes a die with the given face labels available by name.
+// RegisterDie panics if the die has no faces or a die is already registered
+// with the name.
+func RegisterDie(name, description string, faces []string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if len(faces) == 0 {
+		panic("cardware: RegisterDie die has no faces")
+	}
+	key := strings.ToLower(name)
+	if _, dup := dice[key]; dup {
+		panic("cardware: RegisterDie called twice for die " + name)
+	}
+	f := make([]string, len(faces))
+	copy(f, faces)
+	dice[key] = dieEntry{Device{name, description}, f}
+}
+
+// NewRegisteredDeck builds a new deck of the type registered with the name.
+func NewRegisteredDeck(name string) (*Deck, error) {
+	registryMu.RLock()
+	e, ok := decks[strings.ToLower(name)]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("deck \"%s\" is %w", name, ErrNotRegistered)
+	}
+	return e.new(), nil
+}
+
+// RegisteredDieFaces returns the face labels of the die registered with the name.
+func RegisteredDieFaces(name string) ([]string, error) {
+	registryMu.RLock()
+	e, ok := dice[strings.ToLower(name)]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("die \"%s\" is %w", name, ErrNotRegistered)
+	}
+	f := make([]string, len(e.faces))
+	copy(f, e.faces)
+	return f, nil
+}
+
+// RegisteredDecks returns the registered decks, sorted by name.
+func RegisteredDecks() []Device {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	out := make([]Device, 0, len(decks))
+	for _, e := range decks {
+		out = append(out, e.Device)
+	}
+	sortDevices(out)
+	return out
+}
+
+// RegisteredDice returns the registered dice, sorted by name.
+func RegisteredDice() []Device {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	out := make([]Device, 0, len(dice))
+	for _, e := range dice {
+		out = append(out, e.Device)
+	}
+	sortDevices(out)
+	return out
+}
+
+// WriteDevices writes a heading followed by the name and description of each
+// device, in the format of flag usage messages.
+func WriteDevices(w io.Writer, heading string, devices []Device) {
+	fmt.Fprintf(w, "%s\n", heading)
+	for _, d := range devices {
+		fmt.Fprintf(w, "  %s\n    \t%s\n", d.Name, d.Description)
+	}
+}
+
+func sortDevices(d []Device) {
+	sort.Slice(d, func(i, j int) bool { return strings.ToLower(d[i].Name) < strings.ToLower(d[j].Name) })
+}
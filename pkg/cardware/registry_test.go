@@ -0,0 +1,67 @@
+package cardware
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNewRegisteredDeck(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    int
+		wantErr error
+	}{
+		{"french", 52, nil},
+		{"Tarot", 78, nil},
+		{"PINOCHLE", 48, nil},
+		{"bogus", 0, ErrNotRegistered},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewRegisteredDeck(tt.name)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewRegisteredDeck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && d.MaxDraws() != tt.want {
+				t.Errorf("NewRegisteredDeck().MaxDraws() = %v, want %v", d.MaxDraws(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisteredDieFaces(t *testing.T) {
+	if got, err := RegisteredDieFaces("f"); err != nil || !reflect.DeepEqual(got, FudgeFaces) {
+		t.Errorf("RegisteredDieFaces(f) = %v, %v, want %v", got, err, FudgeFaces)
+	}
+	if _, err := RegisteredDieFaces("d6"); !errors.Is(err, ErrNotRegistered) {
+		t.Errorf("RegisteredDieFaces(d6) error = %v, want %v", err, ErrNotRegistered)
+	}
+}
+
+func TestRegisterDeck(t *testing.T) {
+	RegisterDeck("test-deck", "two cards", func() *Deck { return &Deck{cards: []Card{'a', 'b'}} })
+	d, err := NewRegisteredDeck("test-deck")
+	if err != nil || d.MaxDraws() != 2 {
+		t.Fatalf("NewRegisteredDeck(test-deck) = %v, %v", d, err)
+	}
+	found := false
+	for i, dev := range RegisteredDecks() {
+		if i > 0 && RegisteredDecks()[i-1].Name > dev.Name {
+			t.Errorf("RegisteredDecks() not sorted: %v", RegisteredDecks())
+		}
+		if dev == (Device{"test-deck", "two cards"}) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("RegisteredDecks() = %v, want test-deck", RegisteredDecks())
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("RegisterDeck() twice did not panic")
+		}
+	}()
+	RegisterDeck("Test-Deck", "again", NewStandardFrenchDeck)
+}
@@ -0,0 +1,252 @@
+package cardware
+
+import (
+	"math/big"
+	"math/rand"
+	"sort"
+)
+
+// DrawMode returns the dice bag's draw mode.
+func (d *DiceBag) DrawMode() DrawMode {
+	return d.mode
+}
+
+// SetDrawMode sets how the dice are rolled and restarts enumeration.
+//
+// In Unordered mode, identical dice (dice with the same faces) are rolled
+// together and cannot be told apart, so their faces are sorted and an outcome
+// is a multiset of faces. Such outcomes are not equally likely: on 2d6, a 1 and
+// a 2 can be rolled two ways but a double 1 only one way. To keep selection
+// uniform, only the most numerous class of equally likely outcomes is counted,
+// enumerated, and drawn; rolls outside that class must be rerolled. Weight gives
+// the relative likelihood of any outcome.
+func (d *DiceBag) SetDrawMode(m DrawMode) {
+	d.mode = m
+	d.next = nil
+}
+
+// identical reports whether the ith and jth dice have the same faces.
+func (d *DiceBag) identical(i, j int) bool {
+	if d.dice[i] != d.dice[j] {
+		return false
+	}
+	var li, lj []string
+	if i < len(d.labels) {
+		li = d.labels[i]
+	}
+	if j < len(d.labels) {
+		lj = d.labels[j]
+	}
+	if len(li) != len(lj) {
+		return false
+	}
+	for f := range li {
+		if li[f] != lj[f] {
+			return false
+		}
+	}
+	return true
+}
+
+// groups returns the indices of identical dice among the first k, grouped in
+// order of first appearance.
+func (d *DiceBag) groups(k int) [][]int {
+	groups := make([][]int, 0, k)
+	for i := 0; i < k; i++ {
+		found := false
+		for g, group := range groups {
+			if d.identical(group[0], i) {
+				groups[g] = append(group, i)
+				found = true
+				break
+			}
+		}
+		if !found {
+			groups = append(groups, []int{i})
+		}
+	}
+	return groups
+}
+
+// faces returns the face of each die in an outcome.
+func (d *DiceBag) faces(outcome []rune) []int {
+	f := make([]int, len(outcome))
+	for i, r := range outcome {
+		f[i] = int(r) - d.offset(i)
+	}
+	return f
+}
+
+// sortRoll sorts the faces of identical dice in an outcome.
+func (d *DiceBag) sortRoll(outcome []rune) {
+	faces := d.faces(outcome)
+	for _, group := range d.groups(len(outcome)) {
+		f := make([]int, len(group))
+		for j, i := range group {
+			f[j] = faces[i]
+		}
+		sort.Ints(f)
+		for j, i := range group {
+			outcome[i] = rune(d.offset(i) + f[j])
+		}
+	}
+}
+
+// Weight returns the number of ways the outcome can be rolled when identical
+// dice cannot be told apart. The probability of the outcome is its weight
+// divided by the product of the number of faces of the dice rolled.
+func (d *DiceBag) Weight(outcome []rune) *big.Int {
+	faces := d.faces(outcome)
+	w := big.NewInt(1)
+	for _, group := range d.groups(len(outcome)) {
+		f := make([]int, len(group))
+		for j, i := range group {
+			f[j] = faces[i]
+		}
+		w.Mul(w, groupWeight(f))
+	}
+	return w
+}
+
+// groupWeight counts the distinct orderings of the faces rolled on a group of
+// identical dice.
+func groupWeight(faces []int) *big.Int {
+	mult := make(map[int]int64)
+	for _, f := range faces {
+		mult[f]++
+	}
+	w := new(big.Int).MulRange(1, int64(len(faces)))
+	m := new(big.Int)
+	for _, n := range mult {
+		w.Quo(w, m.MulRange(1, n))
+	}
+	return w
+}
+
+// rollClass finds the most numerous class of equally likely unordered rolls of
+// the first k dice, returning the weight of each roll in the class and the
+// number of rolls in it. Ties go to the class of more likely rolls.
+func (d *DiceBag) rollClass(k int) (*big.Int, *big.Int) {
+	// classes maps the weight of a roll to the number of rolls with that weight
+	classes := map[string]*big.Int{"1": big.NewInt(1)}
+	for _, group := range d.groups(k) {
+		faces := d.dice[group[0]]
+		counts := make([]int, faces)
+		for f := range counts {
+			counts[f] = len(group)
+		}
+		hg := newHandGenerator(counts, len(group))
+		next := make(map[string]*big.Int)
+		for hg.Next() {
+			gw := groupWeight(hg.Hand())
+			for key, n := range classes {
+				w, _ := new(big.Int).SetString(key, 10)
+				w.Mul(w, gw)
+				if _, ok := next[w.String()]; !ok {
+					next[w.String()] = big.NewInt(0)
+				}
+				next[w.String()].Add(next[w.String()], n)
+			}
+		}
+		classes = next
+	}
+	var weight, count *big.Int
+	for key, n := range classes {
+		w, _ := new(big.Int).SetString(key, 10)
+		if count == nil || n.Cmp(count) > 0 || (n.Cmp(count) == 0 && w.Cmp(weight) > 0) {
+			weight, count = w, n
+		}
+	}
+	return weight, count
+}
+
+// MappedProbability returns the probability that a roll of the first k dice is
+// one of the outcomes counted and enumerated by the dice bag. It is less than
+// one only in Unordered mode, where other rolls must be rerolled.
+func (d *DiceBag) MappedProbability(k int) *big.Rat {
+	if d.mode != Unordered {
+		return big.NewRat(1, 1)
+	}
+	weight, count := d.rollClass(k)
+	total := big.NewInt(1)
+	for _, f := range d.dice[:k] {
+		total.Mul(total, big.NewInt(int64(f)))
+	}
+	return new(big.Rat).SetFrac(new(big.Int).Mul(weight, count), total)
+}
+
+func (d *DiceBag) randomRoll(k int, src rand.Source) []rune {
+	weight, _ := d.rollClass(k)
+	rng := rand.New(src)
+	out := make([]rune, k)
+	for {
+		for i := range out {
+			out[i] = rune(d.offset(i) + rng.Intn(d.dice[i]))
+		}
+		if d.Weight(out).Cmp(weight) == 0 {
+			d.sortRoll(out)
+			return out
+		}
+	}
+}
+
+// rollGenerator enumerates the unordered rolls of the first k dice of a bag,
+// changing the faces of the last group of identical dice fastest.
+type rollGenerator struct {
+	bag    *DiceBag
+	k      int
+	weight *big.Int
+	groups [][]int
+	gens   []*handGenerator
+	begun  bool
+}
+
+func newRollGenerator(bag *DiceBag, k int, weight *big.Int) *rollGenerator {
+	groups := bag.groups(k)
+	return &rollGenerator{bag: bag, k: k, weight: weight, groups: groups, gens: make([]*handGenerator, len(groups))}
+}
+
+func (g *rollGenerator) restart(i int) {
+	counts := make([]int, g.bag.dice[g.groups[i][0]])
+	for f := range counts {
+		counts[f] = len(g.groups[i])
+	}
+	g.gens[i] = newHandGenerator(counts, len(g.groups[i]))
+	g.gens[i].Next()
+}
+
+// Next advances to the next roll, returning false when all rolls have been
+// generated.
+func (g *rollGenerator) Next() bool {
+	if !g.begun {
+		g.begun = true
+		for i := range g.groups {
+			g.restart(i)
+		}
+		return true
+	}
+	for i := len(g.groups) - 1; i >= 0; i-- {
+		if g.gens[i].Next() {
+			for j := i + 1; j < len(g.groups); j++ {
+				g.restart(j)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// Roll returns the current roll as an outcome of the dice bag.
+func (g *rollGenerator) Roll() []rune {
+	out := make([]rune, g.k)
+	for i, group := range g.groups {
+		for j, f := range g.gens[i].Hand() {
+			die := group[j]
+			out[die] = rune(g.bag.offset(die) + g.bag.face(die, f))
+		}
+	}
+	if len(g.bag.order) > 0 {
+		g.bag.sortRoll(out)
+	}
+	return out
+}
@@ -0,0 +1,79 @@
+package cardware
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"os"
+)
+
+// CryptoSource is a rand.Source64 that reads from the system's cryptographically
+// secure random number generator. Seeding has no effect.
+type CryptoSource struct{}
+
+// Seed implements rand.Source interface. It does nothing.
+func (s CryptoSource) Seed(seed int64) {}
+
+// Int63 implements rand.Source interface.
+func (s CryptoSource) Int63() int64 {
+	return int64(s.Uint64() & ^uint64(1<<63))
+}
+
+// Uint64 implements rand.Source64 interface. It panics if the system's random
+// number generator cannot be read.
+func (s CryptoSource) Uint64() (v uint64) {
+	err := binary.Read(crand.Reader, binary.BigEndian, &v)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// NewSource returns a source of randomness and the seed it was made from. If
+// seedFile is given, the source is an HMAC_DRBG seeded from the file, making
+// the output reproducible. If only record is set, a fresh seed is drawn so that
+// it can be recorded with WriteSeedFile. Otherwise the system's secure random
+// number generator is used directly, and the seed is nil.
+func NewSource(seedFile string, record bool) (rand.Source, []byte, error) {
+	if seedFile == "" && !record {
+		return CryptoSource{}, nil, nil
+	}
+	var seed []byte
+	var err error
+	if seedFile != "" {
+		file, err := os.Open(seedFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("seed file '%s' : %v", seedFile, err)
+		}
+		defer file.Close()
+		seed, err = ReadSeed(file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("seed file '%s' : %v", seedFile, err)
+		}
+	} else {
+		seed, err = NewSeed()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return NewHMACDRBG(seed), seed, nil
+}
+
+// WriteSeedFile writes a seed to the named file, replacing the file if it
+// exists. Programs should only write the seed once their other inputs have been
+// read and checked, so that a run that fails leaves no seed behind.
+func WriteSeedFile(name string, seed []byte) error {
+	file, err := os.Create(name)
+	if err != nil {
+		return fmt.Errorf("seed output file '%s' : %v", name, err)
+	}
+	if err := WriteSeed(file, seed); err != nil {
+		file.Close()
+		return fmt.Errorf("seed output file '%s' : %v", name, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("seed output file '%s' : %v", name, err)
+	}
+	return nil
+}
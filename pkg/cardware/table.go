@@ -0,0 +1,108 @@
+package cardware
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Table maps sequences of outcome names to words, as printed by the table generators.
+type Table map[string]string
+
+// ReadTable parses a card-word table. Both printed formats are accepted:
+// bracketed names (`[A♠][2♡] word`) and names joined with a plus sign
+// (`[1]+A♠+2♡ word`). Parsing stops at the first blank line following the
+// entries, so any symbol table or capital rule printed after them is ignored.
+func ReadTable(r io.Reader) (Table, error) {
+	t := make(Table)
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if len(text) == 0 {
+			if len(t) > 0 {
+				break
+			}
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: expected outcome and word, got '%s'", line, text)
+		}
+		names := splitOutcome(fields[0])
+		key := TableKey(names)
+		if _, ok := t[key]; ok {
+			return nil, fmt.Errorf("line %d: outcome '%s' is repeated", line, fields[0])
+		}
+		t[key] = fields[1]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Lookup returns the word mapped to the outcome with the given names.
+func (t Table) Lookup(names []string) (string, bool) {
+	w, ok := t[TableKey(names)]
+	return w, ok
+}
+
+// LookupTyped returns the word mapped to an outcome of ro typed by the user.
+// The names are read back with ParseOutcome, so cards may be typed with ASCII
+// suits and unordered hands and rolls in any order, and are then translated
+// with ro to find them as printed in the table.
+func (t Table) LookupTyped(ro RandomObject, names []string) (string, error) {
+	outcome, err := ParseOutcome(ro, names)
+	if err != nil {
+		return "", err
+	}
+	printed, err := TranslateOutcome(ro, outcome)
+	if err != nil {
+		return "", err
+	}
+	word, ok := t.Lookup(printed)
+	if !ok {
+		return "", fmt.Errorf("%s not in table", strings.Join(printed, " "))
+	}
+	return word, nil
+}
+
+// Draws returns the number of outcome names that make up each entry in the table.
+func (t Table) Draws() int {
+	for key := range t {
+		return len(strings.Fields(key))
+	}
+	return 0
+}
+
+// TableKey builds the key of an outcome from the names of its elements, ignoring
+// any enclosing brackets.
+func TableKey(names []string) string {
+	trimmed := make([]string, len(names))
+	for i, n := range names {
+		trimmed[i] = strings.TrimSuffix(strings.TrimPrefix(n, "["), "]")
+	}
+	return strings.Join(trimmed, " ")
+}
+
+// splitOutcome splits a printed outcome into the names of its elements. Names
+// are either enclosed in brackets or separated by plus signs, so a bracketed
+// name may itself be a plus sign (as on a Fudge die).
+func splitOutcome(s string) []string {
+	names := make([]string, 0)
+	for len(s) > 0 {
+		end := strings.Index(s, "+")
+		if strings.HasPrefix(s, "[") {
+			end = strings.Index(s, "]") + 1
+		}
+		if end <= 0 {
+			end = len(s)
+		}
+		names = append(names, s[:end])
+		s = strings.TrimPrefix(s[end:], "+")
+	}
+	return names
+}
@@ -0,0 +1,151 @@
+package cardware
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestReadTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		lookup  []string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "bracketed",
+			input:  "[A♠][2♠] apple\n[A♠][3♠] banana\n",
+			lookup: []string{"A♠", "3♠"},
+			want:   "banana",
+		},
+		{
+			name:   "joined",
+			input:  "[1]+A♠ apple\n[2]+A♠ banana\n\n   B  R\nA  !  @\n\nCAPITAL: B\n",
+			lookup: []string{"[2]", "A♠"},
+			want:   "banana",
+		},
+		{
+			name:   "fudge",
+			input:  "[-]+[+]+A♠ apple\n[+]+[-]+A♠ banana\n",
+			lookup: []string{"[+]", "[-]", "A♠"},
+			want:   "banana",
+		},
+		{
+			name:    "repeated",
+			input:   "[A♠] apple\n[A♠] banana\n",
+			wantErr: true,
+		},
+		{
+			name:    "malformed",
+			input:   "[A♠] apple pie\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := ReadTable(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReadTable() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got, _ := table.Lookup(tt.lookup); got != tt.want {
+				t.Errorf("Table.Lookup() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// printTable prints a table of words mapped to the first outcomes of k draws
+// from ro, as the table generators do.
+func printTable(t *testing.T, ro RandomObject, k int, words int) string {
+	it, err := ro.Outcomes(k)
+	if err != nil {
+		t.Fatalf("Outcomes() error = %v", err)
+	}
+	var b strings.Builder
+	for i := 0; i < words; i++ {
+		names, err := TranslateOutcome(ro, it.Next())
+		if err != nil {
+			t.Fatalf("TranslateOutcome() error = %v", err)
+		}
+		fmt.Fprintf(&b, "%s word%d\n", strings.Join(names, "+"), i)
+	}
+	return b.String()
+}
+
+func TestTable_LookupTyped(t *testing.T) {
+	coinsAndDice, _ := NewDiceFromOptions(DiceOptions{Coins: 1, Dice: "d6"})
+	unordered, _ := NewDiceFromOptions(DiceOptions{Dice: "2d6", Unordered: true})
+	hand := NewStandardFrenchDeck()
+	hand.SetDrawMode(Unordered)
+	tests := []struct {
+		name    string
+		ro      RandomObject
+		k       int
+		typed   []string
+		want    string
+		wantErr bool
+	}{
+		{"dice-and-cards", NewCombinedFrom(coinsAndDice, NewStandardFrenchDeck()), 3, []string{"[H]", "[1]", "A♠"}, "word0", false},
+		{"unbracketed", NewCombinedFrom(coinsAndDice, NewStandardFrenchDeck()), 3, []string{"H", "1", "3s"}, "word2", false},
+		{"unordered-dice", NewCombinedFrom(unordered, NewStandardFrenchDeck()), 3, []string{"2", "1", "AS"}, "word0", false},
+		{"unordered-hand", NewCombinedFrom(NewDiceBag(nil), hand), 2, []string{"3♠", "A♠"}, "word1", false},
+		{"card-for-die", NewCombinedFrom(coinsAndDice, NewStandardFrenchDeck()), 3, []string{"H", "A♠", "1"}, "", true},
+		{"not-in-table", NewCombinedFrom(coinsAndDice, NewStandardFrenchDeck()), 3, []string{"T", "6", "K♣"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := ReadTable(strings.NewReader(printTable(t, tt.ro, tt.k, 10)))
+			if err != nil {
+				t.Fatalf("ReadTable() error = %v", err)
+			}
+			got, err := table.LookupTyped(tt.ro, tt.typed)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Table.LookupTyped(%v) error = %v, wantErr %v", tt.typed, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Table.LookupTyped(%v) = %v, want %v", tt.typed, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_RepeatedDeck(t *testing.T) {
+	deck, err := NewDeckFromOptions(DeckOptions{Type: "french", Copies: 2})
+	if err != nil {
+		t.Fatalf("NewDeckFromOptions() error = %v", err)
+	}
+	device := NewCombinedFrom(NewDiceBag(nil), deck)
+	table, err := ReadTable(strings.NewReader(printTable(t, device, 2, 52*52)))
+	if err != nil {
+		t.Fatalf("ReadTable() error = %v", err)
+	}
+	if got, err := table.LookupTyped(device, []string{"AS", "A♠"}); err != nil || got != "word0" {
+		t.Errorf("Table.LookupTyped([AS A♠]) = %v, %v, want word0", got, err)
+	}
+	// draws from the double deck find the entries with repeated cards
+	src := rand.NewSource(1)
+	for i := 0; ; i++ {
+		if i == 100000 {
+			t.Fatalf("RandomOutcome() drew no repeated card in %d draws", i)
+		}
+		o, err := device.RandomOutcome(2, src)
+		if err != nil {
+			t.Fatalf("RandomOutcome() error = %v", err)
+		}
+		if o[0] != o[1] {
+			continue
+		}
+		names, _ := TranslateOutcome(device, o)
+		if _, ok := table.Lookup(names); !ok {
+			t.Errorf("Table.Lookup(%v) found no word", names)
+		}
+		break
+	}
+}
@@ -0,0 +1,59 @@
+package cardware
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// VirtualDeck is a shuffled deck from which cards are dealt one at a time
+// without replacement, like a real deck being dealt from.
+type VirtualDeck struct {
+	Drawer
+	deck  *Deck
+	dealt int
+}
+
+// NewVirtualDeck builds a virtual deck from a shuffled copy of d. The original
+// deck is not modified.
+func NewVirtualDeck(d *Deck, src rand.Source) *VirtualDeck {
+	deck := d.clone()
+	deck.Shuffle(src)
+	return &VirtualDeck{deck: deck}
+}
+
+// HasRemaining implements Drawer interface.
+func (v *VirtualDeck) HasRemaining() bool {
+	return v.dealt < len(v.deck.cards)
+}
+
+// Remaining returns the number of cards that have not yet been dealt.
+func (v *VirtualDeck) Remaining() int {
+	return len(v.deck.cards) - v.dealt
+}
+
+// DrawCard deals the next card from the deck.
+func (v *VirtualDeck) DrawCard() (Card, error) {
+	if !v.HasRemaining() {
+		return 0, fmt.Errorf("no cards remaining")
+	}
+	c := v.deck.Card(v.dealt)
+	v.dealt++
+	return c, nil
+}
+
+// Draw implements Drawer interface. It deals the next card from the deck and
+// returns its translated name.
+func (v *VirtualDeck) Draw() (string, error) {
+	c, err := v.DrawCard()
+	if err != nil {
+		return "", err
+	}
+	return v.deck.Translate(rune(c))
+}
+
+// Dealt returns the cards dealt so far, in the order they were dealt.
+func (v *VirtualDeck) Dealt() []Card {
+	out := make([]Card, v.dealt)
+	copy(out, v.deck.cards[:v.dealt])
+	return out
+}
@@ -0,0 +1,54 @@
+package cardware
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestVirtualDeck_Draw(t *testing.T) {
+	deck := NewStandardFrenchDeck()
+	v := NewVirtualDeck(deck, rand.NewSource(42))
+
+	seen := make(map[string]bool)
+	for v.HasRemaining() {
+		name, err := v.Draw()
+		if err != nil {
+			t.Fatalf("VirtualDeck.Draw() error = %v", err)
+		}
+		if seen[name] {
+			t.Errorf("VirtualDeck.Draw() dealt %s twice", name)
+		}
+		seen[name] = true
+	}
+	if len(seen) != deck.MaxDraws() {
+		t.Errorf("VirtualDeck dealt %d cards, want %d", len(seen), deck.MaxDraws())
+	}
+	if len(v.Dealt()) != deck.MaxDraws() {
+		t.Errorf("VirtualDeck.Dealt() has %d cards, want %d", len(v.Dealt()), deck.MaxDraws())
+	}
+	if _, err := v.Draw(); err == nil {
+		t.Errorf("VirtualDeck.Draw() from empty deck succeeded")
+	}
+	if deck.Card(0) != Card(FrenchCards[0]) {
+		t.Errorf("NewVirtualDeck() modified the original deck")
+	}
+}
+
+func TestNewVirtualDeck_Copy(t *testing.T) {
+	deck := NewPinochleDeck()
+	deck.SetDrawMode(Unordered)
+	v := NewVirtualDeck(deck, rand.NewSource(42))
+
+	if got := v.deck.DrawMode(); got != Unordered {
+		t.Errorf("NewVirtualDeck() draw mode = %v, want %v", got, Unordered)
+	}
+	if got, want := v.deck.Values(), deck.Values(); len(got) != len(want) {
+		t.Errorf("NewVirtualDeck() values = %v, want %v", got, want)
+	}
+	if _, err := v.deck.Parse("9S"); err != nil {
+		t.Errorf("NewVirtualDeck() deck cannot parse names: %v", err)
+	}
+	if v.Remaining() != deck.MaxDraws() {
+		t.Errorf("VirtualDeck.Remaining() = %d, want %d", v.Remaining(), deck.MaxDraws())
+	}
+}